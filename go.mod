@@ -0,0 +1,15 @@
+module github.com/sjoerd2025/antigravity-jules-orchestration
+
+go 1.25.0
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.4
+	go.opentelemetry.io/otel v1.46.0
+	google.golang.org/protobuf v1.36.12
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+)
@@ -0,0 +1,105 @@
+package schemas
+
+import "fmt"
+
+// MissingParamError reports that a requested parameter is absent.
+type MissingParamError struct {
+	Key string
+}
+
+func (e *MissingParamError) Error() string {
+	return fmt.Sprintf("parameter %q is missing", e.Key)
+}
+
+// ParamTypeError reports that a parameter holds a value of the wrong type.
+type ParamTypeError struct {
+	Key  string
+	Want string
+	Got  string
+}
+
+func (e *ParamTypeError) Error() string {
+	return fmt.Sprintf("parameter %q: expected %s, got %s", e.Key, e.Want, e.Got)
+}
+
+func (m McpExecute) param(key string) (any, error) {
+	v, ok := m.Parameters[key]
+	if !ok {
+		return nil, &MissingParamError{Key: key}
+	}
+	return v, nil
+}
+
+func paramTypeError(key, want string, v any) error {
+	got := jsonKind(v)
+	if got == "" {
+		got = fmt.Sprintf("%T", v)
+	}
+	return &ParamTypeError{Key: key, Want: want, Got: got}
+}
+
+// GetString returns the string parameter named key. Like GetInt, it accepts
+// named string types and pointers to strings.
+func (m McpExecute) GetString(key string) (string, error) {
+	v, err := m.param(key)
+	if err != nil {
+		return "", err
+	}
+	s, ok := stringValue(v)
+	if !ok {
+		return "", paramTypeError(key, kindString, v)
+	}
+	return s, nil
+}
+
+// GetInt returns the integer parameter named key. It accepts Go integer
+// types as well as JSON numbers (float64 or json.Number) with no fractional
+// part that fit in an int64.
+func (m McpExecute) GetInt(key string) (int64, error) {
+	v, err := m.param(key)
+	if err != nil {
+		return 0, err
+	}
+	if n, ok := asInt64(v); ok {
+		return n, nil
+	}
+	return 0, paramTypeError(key, kindInteger, v)
+}
+
+// GetBool returns the boolean parameter named key. It accepts named bool
+// types and pointers to bools.
+func (m McpExecute) GetBool(key string) (bool, error) {
+	v, err := m.param(key)
+	if err != nil {
+		return false, err
+	}
+	b, ok := boolValue(v)
+	if !ok {
+		return false, paramTypeError(key, kindBoolean, v)
+	}
+	return b, nil
+}
+
+// GetStringSlice returns the parameter named key as a slice of strings. It
+// accepts []string as well as a JSON array whose elements are all strings.
+func (m McpExecute) GetStringSlice(key string) ([]string, error) {
+	v, err := m.param(key)
+	if err != nil {
+		return nil, err
+	}
+	switch x := v.(type) {
+	case []string:
+		return x, nil
+	case []any:
+		out := make([]string, len(x))
+		for i, item := range x {
+			s, ok := stringValue(item)
+			if !ok {
+				return nil, paramTypeError(fmt.Sprintf("%s[%d]", key, i), kindString, item)
+			}
+			out[i] = s
+		}
+		return out, nil
+	}
+	return nil, paramTypeError(key, "array of strings", v)
+}
@@ -0,0 +1,89 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParamAccessors(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"name":   "jules",
+		"count":  float64(3),
+		"native": 7,
+		"number": json.Number("12"),
+		"frac":   1.5,
+		"ok":     true,
+		"tags":   []any{"a", "b"},
+		"mixed":  []any{"a", 1},
+	}}
+
+	if s, err := m.GetString("name"); err != nil || s != "jules" {
+		t.Errorf("GetString = %q, %v", s, err)
+	}
+	for key, want := range map[string]int64{"count": 3, "native": 7, "number": 12} {
+		if n, err := m.GetInt(key); err != nil || n != want {
+			t.Errorf("GetInt(%s) = %d, %v", key, n, err)
+		}
+	}
+	if b, err := m.GetBool("ok"); err != nil || !b {
+		t.Errorf("GetBool = %v, %v", b, err)
+	}
+	if s, err := m.GetStringSlice("tags"); err != nil || !reflect.DeepEqual(s, []string{"a", "b"}) {
+		t.Errorf("GetStringSlice = %v, %v", s, err)
+	}
+
+	var missing *MissingParamError
+	if _, err := m.GetString("absent"); !errors.As(err, &missing) {
+		t.Errorf("expected MissingParamError, got %v", err)
+	}
+	var wrong *ParamTypeError
+	for _, err := range []error{
+		func() error { _, err := m.GetString("count"); return err }(),
+		func() error { _, err := m.GetInt("frac"); return err }(),
+		func() error { _, err := m.GetInt("name"); return err }(),
+		func() error { _, err := m.GetBool("name"); return err }(),
+		func() error { _, err := m.GetStringSlice("mixed"); return err }(),
+	} {
+		if !errors.As(err, &wrong) {
+			t.Errorf("expected ParamTypeError, got %v", err)
+		}
+	}
+}
+
+func TestParamAccessorsPointers(t *testing.T) {
+	type label string
+	type flag bool
+	name, on, n := "jules", true, 7
+	var nilString *string
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"name":  &name,
+		"label": label("core"),
+		"ok":    &on,
+		"flag":  flag(true),
+		"n":     &n,
+		"tags":  []any{&name, label("core")},
+		"nil":   nilString,
+	}}
+	for key, want := range map[string]string{"name": "jules", "label": "core"} {
+		if s, err := m.GetString(key); err != nil || s != want {
+			t.Errorf("GetString(%s) = %q, %v", key, s, err)
+		}
+	}
+	for _, key := range []string{"ok", "flag"} {
+		if b, err := m.GetBool(key); err != nil || !b {
+			t.Errorf("GetBool(%s) = %v, %v", key, b, err)
+		}
+	}
+	if got, err := m.GetInt("n"); err != nil || got != 7 {
+		t.Errorf("GetInt(n) = %d, %v", got, err)
+	}
+	if s, err := m.GetStringSlice("tags"); err != nil || !reflect.DeepEqual(s, []string{"jules", "core"}) {
+		t.Errorf("GetStringSlice = %v, %v", s, err)
+	}
+	var wrong *ParamTypeError
+	if _, err := m.GetString("nil"); !errors.As(err, &wrong) {
+		t.Errorf("nil *string: expected ParamTypeError, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+)
+
+// McpExecuteGuarded defines an MCP tool execution that may need approval
+// before it runs.
+type McpExecuteGuarded struct {
+	McpExecute
+	ApprovalRequired bool   `json:"approvalRequired,omitempty" doc:"Whether the execution must be approved before it runs."`
+	ApprovalReason   string `json:"approvalReason,omitempty" doc:"Why approval is required, shown to the approver."`
+}
+
+// Approver decides whether a guarded execution may run.
+type Approver interface {
+	Approve(ctx context.Context, m McpExecute) (bool, error)
+}
+
+// ApproverFunc adapts an ordinary function to the Approver interface.
+type ApproverFunc func(ctx context.Context, m McpExecute) (bool, error)
+
+// Approve calls f(ctx, m).
+func (f ApproverFunc) Approve(ctx context.Context, m McpExecute) (bool, error) {
+	return f(ctx, m)
+}
+
+type approvalContext struct{}
+
+// approvalGuard is the guard carried in a context by WithApprovalRequired.
+type approvalGuard struct {
+	required bool
+	reason   string
+}
+
+// WithApprovalRequired returns a context marking the executions run with it
+// as guarded, so an ApprovalExecutor asks its Approver before running them.
+// The reason is available to the Approver through ApprovalReasonFromContext.
+func WithApprovalRequired(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, approvalContext{}, approvalGuard{required: true, reason: reason})
+}
+
+// ApprovalRequiredFromContext reports whether ctx carries an approval guard.
+func ApprovalRequiredFromContext(ctx context.Context) bool {
+	g, _ := ctx.Value(approvalContext{}).(approvalGuard)
+	return g.required
+}
+
+// ApprovalReasonFromContext returns the ApprovalReason of the guarded
+// execution being approved, for use by an Approver.
+func ApprovalReasonFromContext(ctx context.Context) string {
+	g, _ := ctx.Value(approvalContext{}).(approvalGuard)
+	return g.reason
+}
+
+// ApprovalExecutor is an Executor that asks an Approver before running
+// guarded executions. A denied execution yields a result with a Cancelled
+// McpError and the wrapped Executor is not called. Without an Approver every
+// guarded execution is denied.
+type ApprovalExecutor struct {
+	next     Executor
+	approver Approver
+}
+
+// NewApprovalExecutor returns an ApprovalExecutor wrapping next.
+func NewApprovalExecutor(next Executor, approver Approver) *ApprovalExecutor {
+	return &ApprovalExecutor{next: next, approver: approver}
+}
+
+// Execute runs m, first asking the Approver if ctx carries a guard set by
+// WithApprovalRequired. The guard is cleared once approved, so the wrapped
+// Executor sees an ordinary context. An error from the Approver is returned
+// wrapped.
+func (e *ApprovalExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	g, _ := ctx.Value(approvalContext{}).(approvalGuard)
+	if !g.required {
+		return e.next.Execute(ctx, m)
+	}
+	ok := false
+	if e.approver != nil {
+		var err error
+		ok, err = e.approver.Approve(ctx, m)
+		if err != nil {
+			return McpResult{}, fmt.Errorf("approve %s: %w", m.Tool, err)
+		}
+	}
+	if !ok {
+		msg := "approval denied"
+		if g.reason != "" {
+			msg += ": " + g.reason
+		}
+		return ErrorResult(m.Tool, &McpError{Code: Cancelled, Message: msg}), nil
+	}
+	return e.next.Execute(context.WithValue(ctx, approvalContext{}, approvalGuard{}), m)
+}
+
+// ExecuteGuarded runs g, first asking the Approver if g.ApprovalRequired is
+// set. It is Execute with the guard taken from g instead of the context.
+func (e *ApprovalExecutor) ExecuteGuarded(ctx context.Context, g McpExecuteGuarded) (McpResult, error) {
+	if g.ApprovalRequired {
+		ctx = WithApprovalRequired(ctx, g.ApprovalReason)
+	}
+	return e.Execute(ctx, g.McpExecute)
+}
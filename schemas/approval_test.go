@@ -0,0 +1,94 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestApprovalExecutor(t *testing.T) {
+	var calls int
+	next := countingExecutor(&calls, func(m McpExecute) McpResult { return McpResult{Tool: m.Tool, Output: "done"} })
+	var reasons []string
+	approver := ApproverFunc(func(ctx context.Context, m McpExecute) (bool, error) {
+		reasons = append(reasons, ApprovalReasonFromContext(ctx))
+		if m.Tool == "broken" {
+			return false, errors.New("approval service down")
+		}
+		return m.Parameters["env"] != "prod", nil
+	})
+	e := NewApprovalExecutor(next, approver)
+	ctx := context.Background()
+	guard := func(env string) McpExecuteGuarded {
+		return McpExecuteGuarded{
+			McpExecute:       McpExecute{Tool: "drop_table", Parameters: map[string]any{"env": env}},
+			ApprovalRequired: true,
+			ApprovalReason:   "destructive",
+		}
+	}
+
+	r, err := e.ExecuteGuarded(ctx, guard("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serr, failed := r.StructuredError()
+	if !failed || serr.Code != Cancelled || serr.Message != "approval denied: destructive" {
+		t.Errorf("denied result = %+v", r)
+	}
+	if calls != 0 {
+		t.Error("denied call reached the wrapped executor")
+	}
+
+	if r, err := e.ExecuteGuarded(ctx, guard("staging")); err != nil || r.Output != "done" {
+		t.Errorf("approved call = %+v, %v", r, err)
+	}
+	unguarded := guard("prod")
+	unguarded.ApprovalRequired = false
+	if r, err := e.ExecuteGuarded(ctx, unguarded); err != nil || r.IsError() {
+		t.Errorf("unguarded call = %+v, %v", r, err)
+	}
+	if calls != 2 || len(reasons) != 2 || reasons[0] != "destructive" {
+		t.Errorf("calls = %d, reasons = %q", calls, reasons)
+	}
+
+	broken := guard("staging")
+	broken.Tool = "broken"
+	if _, err := e.ExecuteGuarded(ctx, broken); err == nil {
+		t.Error("expected approver error")
+	}
+}
+
+func TestApprovalExecutorContextGuard(t *testing.T) {
+	var calls int
+	var guarded bool
+	next := ExecutorFunc(func(ctx context.Context, m McpExecute) (McpResult, error) {
+		calls++
+		guarded = guarded || ApprovalRequiredFromContext(ctx)
+		return McpResult{Tool: m.Tool, Output: "done"}, nil
+	})
+	approver := ApproverFunc(func(ctx context.Context, m McpExecute) (bool, error) {
+		return m.Tool == "safe", nil
+	})
+	e := NewApprovalExecutor(next, approver)
+	ctx := WithApprovalRequired(context.Background(), "destructive")
+
+	if r, err := e.Execute(ctx, McpExecute{Tool: "drop_table"}); err != nil || !r.IsError() {
+		t.Errorf("guarded Execute = %+v, %v", r, err)
+	}
+	b := McpBatch{Executes: []McpExecute{{Tool: "drop_table"}, {Tool: "safe"}}}
+	out := RunBatch(ctx, e, b, RunOptions{})
+	if !out.Results[0].IsError() || out.Results[1].Output != "done" {
+		t.Errorf("guarded batch = %+v", out.Results)
+	}
+	if calls != 1 || guarded {
+		t.Errorf("calls = %d, guard passed on = %v", calls, guarded)
+	}
+
+	open := NewApprovalExecutor(next, nil)
+	if r, err := open.Execute(ctx, McpExecute{Tool: "safe"}); err != nil || !r.IsError() {
+		t.Errorf("Execute without approver = %+v, %v", r, err)
+	}
+	if r, err := open.Execute(context.Background(), McpExecute{Tool: "safe"}); err != nil || r.IsError() {
+		t.Errorf("unguarded Execute = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,34 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+)
+
+// McpBatch defines a group of MCP tool executions dispatched together.
+type McpBatch struct {
+	Executes   []McpExecute `json:"executes" doc:"The tool executions in the batch."`
+	Sequential bool         `json:"sequential,omitempty" doc:"Whether the executions must run in order rather than concurrently."`
+}
+
+// McpBatchResult defines the results of an McpBatch, in execution order.
+type McpBatchResult struct {
+	Results []McpResult `json:"results" doc:"The result of each execution, in the order of the batch."`
+}
+
+// Validate checks that the batch is non-empty and every execution is well-formed.
+func (b McpBatch) Validate() error {
+	if len(b.Executes) == 0 {
+		return &ValidationError{Field: "executes", Message: "must not be empty"}
+	}
+	for i, m := range b.Executes {
+		if err := m.Validate(); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				return &ValidationError{Field: fmt.Sprintf("executes[%d].%s", i, verr.Field), Message: verr.Message}
+			}
+			return fmt.Errorf("executes[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
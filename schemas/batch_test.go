@@ -0,0 +1,30 @@
+package schemas
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMcpBatchValidate(t *testing.T) {
+	ok := McpExecute{Tool: "ok"}
+	tests := []struct {
+		name  string
+		batch McpBatch
+		field string
+	}{
+		{"nil", McpBatch{}, "executes"},
+		{"empty", McpBatch{Executes: []McpExecute{}}, "executes"},
+		{"tool", McpBatch{Executes: []McpExecute{ok, {Tool: ""}}}, "executes[1].tool"},
+		{"parameters", McpBatch{Executes: []McpExecute{{Tool: "t", Parameters: map[string]any{"": 1}}}}, "executes[0].parameters"},
+		{"schemaVersion", McpBatch{Executes: []McpExecute{ok, ok, {Tool: "t", SchemaVersion: -1}}}, "executes[2].schemaVersion"},
+	}
+	for _, tt := range tests {
+		var verr *ValidationError
+		if err := tt.batch.Validate(); !errors.As(err, &verr) || verr.Field != tt.field {
+			t.Errorf("%s: got %v, want a ValidationError on %s", tt.name, err, tt.field)
+		}
+	}
+	if err := (McpBatch{Executes: []McpExecute{ok, ok}}).Validate(); err != nil {
+		t.Errorf("valid batch: %v", err)
+	}
+}
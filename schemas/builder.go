@@ -0,0 +1,65 @@
+package schemas
+
+import "fmt"
+
+// McpExecuteBuilder constructs an McpExecute through chained calls. The zero
+// value is ready to use.
+type McpExecuteBuilder struct {
+	tool   string
+	params map[string]any
+	err    error
+}
+
+// NewMcpExecuteBuilder returns an empty builder.
+func NewMcpExecuteBuilder() *McpExecuteBuilder {
+	return &McpExecuteBuilder{}
+}
+
+// Tool sets the name of the tool to execute.
+func (b *McpExecuteBuilder) Tool(name string) *McpExecuteBuilder {
+	b.tool = name
+	return b
+}
+
+// Param adds a single parameter. Adding the same key twice is an error
+// reported by Build.
+func (b *McpExecuteBuilder) Param(key string, value any) *McpExecuteBuilder {
+	if b.params == nil {
+		b.params = make(map[string]any)
+	}
+	if _, dup := b.params[key]; dup && b.err == nil {
+		b.err = &ValidationError{Field: "parameters", Message: fmt.Sprintf("duplicate key %q", key)}
+	}
+	b.params[key] = value
+	return b
+}
+
+// Params adds every entry of params as if by Param.
+func (b *McpExecuteBuilder) Params(params map[string]any) *McpExecuteBuilder {
+	for k, v := range params {
+		b.Param(k, v)
+	}
+	return b
+}
+
+// Build returns the constructed McpExecute, or the first error encountered.
+func (b *McpExecuteBuilder) Build() (McpExecute, error) {
+	if b.err != nil {
+		return McpExecute{}, b.err
+	}
+	if b.tool == "" {
+		return McpExecute{}, &ValidationError{Field: "tool", Message: "not set"}
+	}
+	var params map[string]any
+	if len(b.params) > 0 {
+		params = make(map[string]any, len(b.params))
+		for k, v := range b.params {
+			params[k] = v
+		}
+	}
+	m := McpExecute{Tool: b.tool, Parameters: params}
+	if err := m.Validate(); err != nil {
+		return McpExecute{}, err
+	}
+	return m, nil
+}
@@ -0,0 +1,56 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMcpExecuteBuilder(t *testing.T) {
+	b := NewMcpExecuteBuilder().
+		Tool("search").
+		Param("query", "go").
+		Params(map[string]any{"limit": 10, "lang": "en"})
+	m, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := McpExecute{Tool: "search", Parameters: map[string]any{"query": "go", "limit": 10, "lang": "en"}}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("Build = %+v, want %+v", m, want)
+	}
+	m.Parameters["query"] = "changed"
+	if again, _ := b.Build(); again.Parameters["query"] != "go" {
+		t.Error("Build result aliases the builder's parameters")
+	}
+
+	var empty McpExecuteBuilder
+	if m, err := empty.Tool("ping").Build(); err != nil || m.Parameters != nil {
+		t.Errorf("zero-value builder = %+v, %v", m, err)
+	}
+}
+
+func TestMcpExecuteBuilderErrors(t *testing.T) {
+	var verr *ValidationError
+	_, err := NewMcpExecuteBuilder().Param("q", "go").Build()
+	if !errors.As(err, &verr) || verr.Field != "tool" {
+		t.Errorf("missing tool: got %v", err)
+	}
+
+	_, err = NewMcpExecuteBuilder().Tool("t").Param("a", 1).Param("a", 2).Build()
+	if !errors.As(err, &verr) || verr.Field != "parameters" || verr.Message != `duplicate key "a"` {
+		t.Errorf("duplicate Param: got %v", err)
+	}
+
+	// A key repeated through Params overwrites the earlier value like Param
+	// does, but Build still fails, reporting the first duplicate even if
+	// later calls add more.
+	_, err = NewMcpExecuteBuilder().Tool("t").
+		Param("b", 1).
+		Params(map[string]any{"b": 2}).
+		Param("c", 3).Param("c", 4).
+		Build()
+	if !errors.As(err, &verr) || verr.Message != `duplicate key "b"` {
+		t.Errorf("duplicate via Params: got %v", err)
+	}
+}
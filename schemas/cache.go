@@ -0,0 +1,149 @@
+package schemas
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheOptions configures a CachingExecutor.
+type CacheOptions struct {
+	// TTL is how long an entry stays valid; zero means entries never expire.
+	TTL time.Duration
+	// MaxEntries bounds the cache size, evicting the least recently used
+	// entry first; zero means unbounded.
+	MaxEntries int
+	// CacheErrorCodes lists the structured error codes whose results are
+	// cached. Failed results are otherwise never cached.
+	CacheErrorCodes []McpErrorCode
+	// Now returns the current time; it defaults to time.Now.
+	Now func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	tool    string
+	result  McpResult
+	expires time.Time
+}
+
+// CachingExecutor is an Executor that caches the results of another
+// Executor keyed by McpExecute.Fingerprint. It is safe for concurrent use.
+type CachingExecutor struct {
+	next       Executor
+	ttl        time.Duration
+	maxEntries int
+	errorCodes map[McpErrorCode]struct{}
+	now        func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// NewCachingExecutor returns a CachingExecutor wrapping next.
+func NewCachingExecutor(next Executor, opts CacheOptions) *CachingExecutor {
+	e := &CachingExecutor{
+		next:       next,
+		ttl:        opts.TTL,
+		maxEntries: opts.MaxEntries,
+		errorCodes: make(map[McpErrorCode]struct{}, len(opts.CacheErrorCodes)),
+		now:        opts.Now,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+	if e.now == nil {
+		e.now = time.Now
+	}
+	for _, code := range opts.CacheErrorCodes {
+		e.errorCodes[code] = struct{}{}
+	}
+	return e
+}
+
+// Execute returns the cached result for m if there is a live entry, and
+// otherwise delegates to the wrapped Executor.
+func (e *CachingExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	key := m.Fingerprint()
+	if key == "" {
+		return e.next.Execute(ctx, m)
+	}
+	if r, ok := e.get(key); ok {
+		return r, nil
+	}
+	r, err := e.next.Execute(ctx, m)
+	if err == nil && e.cacheable(r) {
+		e.put(key, m.Tool, r)
+	}
+	return r, err
+}
+
+// InvalidateTool drops every cached entry for the named tool.
+func (e *CachingExecutor) InvalidateTool(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for el := e.lru.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.(*cacheEntry).tool == name {
+			e.remove(el)
+		}
+		el = next
+	}
+}
+
+// Len returns the number of cached entries, including expired ones not yet evicted.
+func (e *CachingExecutor) Len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lru.Len()
+}
+
+func (e *CachingExecutor) cacheable(r McpResult) bool {
+	serr, failed := r.StructuredError()
+	if !failed {
+		return true
+	}
+	_, ok := e.errorCodes[serr.Code]
+	return ok
+}
+
+func (e *CachingExecutor) get(key string) (McpResult, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	el, ok := e.entries[key]
+	if !ok {
+		return McpResult{}, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && !e.now().Before(entry.expires) {
+		e.remove(el)
+		return McpResult{}, false
+	}
+	e.lru.MoveToFront(el)
+	return entry.result, true
+}
+
+func (e *CachingExecutor) put(key, tool string, r McpResult) {
+	entry := &cacheEntry{key: key, tool: tool, result: r}
+	if e.ttl > 0 {
+		entry.expires = e.now().Add(e.ttl)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if el, ok := e.entries[key]; ok {
+		el.Value = entry
+		e.lru.MoveToFront(el)
+		return
+	}
+	e.entries[key] = e.lru.PushFront(entry)
+	if e.maxEntries > 0 && e.lru.Len() > e.maxEntries {
+		e.remove(e.lru.Back())
+	}
+}
+
+// remove deletes el from the cache; e.mu must be held.
+func (e *CachingExecutor) remove(el *list.Element) {
+	e.lru.Remove(el)
+	delete(e.entries, el.Value.(*cacheEntry).key)
+}
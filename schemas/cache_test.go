@@ -0,0 +1,80 @@
+package schemas
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func countingExecutor(calls *int, result func(m McpExecute) McpResult) Executor {
+	return ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		*calls++
+		return result(m), nil
+	})
+}
+
+func TestCachingExecutor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var calls int
+	next := countingExecutor(&calls, func(m McpExecute) McpResult {
+		if m.Tool == "flaky" {
+			return ErrorResult(m.Tool, &McpError{Code: Timeout, Message: "slow"})
+		}
+		if m.Tool == "missing" {
+			return ErrorResult(m.Tool, &McpError{Code: ToolNotFound, Message: "no such tool"})
+		}
+		return McpResult{Tool: m.Tool, Output: m.Parameters["q"]}
+	})
+	cache := NewCachingExecutor(next, CacheOptions{
+		TTL:             time.Minute,
+		MaxEntries:      2,
+		CacheErrorCodes: []McpErrorCode{ToolNotFound},
+		Now:             func() time.Time { return now },
+	})
+	exec := func(tool, q string) McpResult {
+		t.Helper()
+		r, err := cache.Execute(ctx, McpExecute{Tool: tool, Parameters: map[string]any{"q": q}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	exec("search", "a")
+	if r := exec("search", "a"); r.Output != "a" || calls != 1 {
+		t.Fatalf("cache miss on repeated call: calls = %d", calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	exec("search", "a")
+	if calls != 2 {
+		t.Errorf("expired entry served: calls = %d", calls)
+	}
+
+	exec("flaky", "x")
+	exec("flaky", "x")
+	if calls != 4 {
+		t.Errorf("uncacheable error cached: calls = %d", calls)
+	}
+	exec("missing", "x")
+	exec("missing", "x")
+	if calls != 5 {
+		t.Errorf("cacheable error not cached: calls = %d", calls)
+	}
+
+	exec("lint", "x")
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2 after eviction", cache.Len())
+	}
+	exec("search", "a")
+	if calls != 7 {
+		t.Errorf("least recently used entry not evicted: calls = %d", calls)
+	}
+
+	cache.InvalidateTool("search")
+	exec("search", "a")
+	if calls != 8 {
+		t.Errorf("invalidated entry served: calls = %d", calls)
+	}
+}
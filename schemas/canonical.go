@@ -0,0 +1,41 @@
+package schemas
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// MarshalCanonical returns a deterministic JSON encoding of the execution
+// request, suitable for use as a cache key or for comparing payloads.
+// Object keys are sorted lexicographically at every nesting level, HTML
+// characters are not escaped and no trailing newline is emitted. An
+// explicit SchemaVersion equal to CurrentSchemaVersion is omitted, so it
+// does not change the encoding.
+func (m McpExecute) MarshalCanonical() ([]byte, error) {
+	if m.SchemaVersion == CurrentSchemaVersion {
+		m.SchemaVersion = 0
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// encoding/json already sorts map keys, including those of nested maps.
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Fingerprint returns the hex-encoded SHA-256 of the canonical encoding.
+// Requests with the same tool and equal parameters share a fingerprint
+// regardless of map ordering. It returns an empty string if the parameters
+// cannot be encoded as JSON.
+func (m McpExecute) Fingerprint() string {
+	data, err := m.MarshalCanonical()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
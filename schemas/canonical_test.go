@@ -0,0 +1,36 @@
+package schemas
+
+import "testing"
+
+func TestMarshalCanonical(t *testing.T) {
+	m := McpExecute{
+		Tool: "render_deploy",
+		Parameters: map[string]any{
+			"service": "api",
+			"config":  map[string]any{"zeta": 1, "alpha": "<b>", "mid": []any{map[string]any{"y": 2, "x": 1}}},
+		},
+	}
+	got, err := m.MarshalCanonical()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"tool":"render_deploy","parameters":{"config":{"alpha":"<b>","mid":[{"x":1,"y":2}],"zeta":1},"service":"api"}}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := McpExecute{Tool: "search", Parameters: map[string]any{"q": "go", "opts": map[string]any{"a": 1, "b": 2}}}
+	b := McpExecute{Tool: "search", Parameters: map[string]any{"opts": map[string]any{"b": 2, "a": 1}, "q": "go"}}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("equal requests produced different fingerprints")
+	}
+	if len(a.Fingerprint()) != 64 {
+		t.Errorf("fingerprint length = %d, want 64", len(a.Fingerprint()))
+	}
+	c := McpExecute{Tool: "search", Parameters: map[string]any{"q": "rust"}}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Error("different requests produced the same fingerprint")
+	}
+}
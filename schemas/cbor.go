@@ -0,0 +1,174 @@
+package schemas
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborExecute is the CBOR wire form of McpExecute. A separate type keeps the
+// codec from recursing into McpExecute.MarshalCBOR.
+type cborExecute struct {
+	Tool          string         `cbor:"tool"`
+	Parameters    map[string]any `cbor:"parameters,omitempty"`
+	SchemaVersion int            `cbor:"schemaVersion,omitempty"`
+}
+
+var (
+	cborEnc = mustCBOREncMode()
+	cborDec = mustCBORDecMode()
+)
+
+func mustCBOREncMode() cbor.EncMode {
+	em, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return em
+}
+
+func mustCBORDecMode() cbor.DecMode {
+	dm, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]any(nil)),
+		IntDec:         cbor.IntDecConvertSigned,
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return dm
+}
+
+// cborEnvelope, cborGuarded and cborWithPolicy are the CBOR wire forms of the
+// wrapper types. The embedded cborExecute is flattened, so each wrapper
+// encodes as a single map extending the McpExecute one. Deadlines are encoded
+// as RFC 3339 strings to keep sub-second precision.
+type cborEnvelope struct {
+	cborExecute
+	CorrelationID  string `cbor:"correlationId,omitempty"`
+	Deadline       string `cbor:"deadline,omitempty"`
+	IdempotencyKey string `cbor:"idempotencyKey,omitempty"`
+}
+
+type cborGuarded struct {
+	cborExecute
+	ApprovalRequired bool   `cbor:"approvalRequired,omitempty"`
+	ApprovalReason   string `cbor:"approvalReason,omitempty"`
+}
+
+type cborWithPolicy struct {
+	cborExecute
+	Policy McpExecutePolicy `cbor:"policy"`
+}
+
+func (m McpExecute) cborWire() (cborExecute, error) {
+	w := cborExecute{Tool: m.Tool, SchemaVersion: m.SchemaVersion}
+	if m.Parameters != nil {
+		params, err := nativeValue(m.Parameters)
+		if err != nil {
+			return cborExecute{}, err
+		}
+		w.Parameters = params.(map[string]any)
+	}
+	return w, nil
+}
+
+func (w cborExecute) execute() McpExecute {
+	return McpExecute{Tool: w.Tool, Parameters: w.Parameters, SchemaVersion: w.SchemaVersion}
+}
+
+// MarshalCBOR encodes the request as deterministic CBOR (RFC 8949 core
+// deterministic encoding). Integers are encoded as CBOR integers and floats
+// as CBOR floats, so the distinction survives a round trip; json.Number
+// values are encoded as whichever of the two represents them exactly.
+func (m McpExecute) MarshalCBOR() ([]byte, error) {
+	w, err := m.cborWire()
+	if err != nil {
+		return nil, err
+	}
+	return cborEnc.Marshal(w)
+}
+
+// UnmarshalCBOR decodes a request produced by MarshalCBOR. Objects decode as
+// map[string]any, arrays as []any, integers as int64, floats as float64 and
+// byte strings as []byte.
+func UnmarshalCBOR(data []byte) (McpExecute, error) {
+	var w cborExecute
+	if err := cborDec.Unmarshal(data, &w); err != nil {
+		return McpExecute{}, err
+	}
+	return w.execute(), nil
+}
+
+// MarshalCBOR encodes the envelope like McpExecute.MarshalCBOR, including
+// its metadata.
+func (e McpExecuteEnvelope) MarshalCBOR() ([]byte, error) {
+	x, err := e.McpExecute.cborWire()
+	if err != nil {
+		return nil, err
+	}
+	w := cborEnvelope{cborExecute: x, CorrelationID: e.CorrelationID, IdempotencyKey: e.IdempotencyKey}
+	if e.Deadline != nil {
+		w.Deadline = e.Deadline.Format(time.RFC3339Nano)
+	}
+	return cborEnc.Marshal(w)
+}
+
+// UnmarshalCBOR decodes an envelope produced by MarshalCBOR.
+func (e *McpExecuteEnvelope) UnmarshalCBOR(data []byte) error {
+	var w cborEnvelope
+	if err := cborDec.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	out := McpExecuteEnvelope{McpExecute: w.execute(), CorrelationID: w.CorrelationID, IdempotencyKey: w.IdempotencyKey}
+	if w.Deadline != "" {
+		d, err := time.Parse(time.RFC3339Nano, w.Deadline)
+		if err != nil {
+			return fmt.Errorf("deadline: %w", err)
+		}
+		out.Deadline = &d
+	}
+	*e = out
+	return nil
+}
+
+// MarshalCBOR encodes the guarded execution like McpExecute.MarshalCBOR,
+// including its approval fields.
+func (g McpExecuteGuarded) MarshalCBOR() ([]byte, error) {
+	x, err := g.McpExecute.cborWire()
+	if err != nil {
+		return nil, err
+	}
+	return cborEnc.Marshal(cborGuarded{cborExecute: x, ApprovalRequired: g.ApprovalRequired, ApprovalReason: g.ApprovalReason})
+}
+
+// UnmarshalCBOR decodes a guarded execution produced by MarshalCBOR.
+func (g *McpExecuteGuarded) UnmarshalCBOR(data []byte) error {
+	var w cborGuarded
+	if err := cborDec.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*g = McpExecuteGuarded{McpExecute: w.execute(), ApprovalRequired: w.ApprovalRequired, ApprovalReason: w.ApprovalReason}
+	return nil
+}
+
+// MarshalCBOR encodes the execution like McpExecute.MarshalCBOR, including
+// its policy.
+func (e McpExecuteWithPolicy) MarshalCBOR() ([]byte, error) {
+	x, err := e.McpExecute.cborWire()
+	if err != nil {
+		return nil, err
+	}
+	return cborEnc.Marshal(cborWithPolicy{cborExecute: x, Policy: e.Policy})
+}
+
+// UnmarshalCBOR decodes an execution produced by MarshalCBOR.
+func (e *McpExecuteWithPolicy) UnmarshalCBOR(data []byte) error {
+	var w cborWithPolicy
+	if err := cborDec.Unmarshal(data, &w); err != nil {
+		return err
+	}
+	*e = McpExecuteWithPolicy{McpExecute: w.execute(), Policy: w.Policy}
+	return nil
+}
@@ -0,0 +1,113 @@
+package schemas
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestCBORRoundTripFromJSON(t *testing.T) {
+	in := `{"tool":"deploy","parameters":{"service":"api","replicas":3,"ratio":0.25,
+		"dryRun":false,"parent":null,"ports":[80,443],"env":{"tags":["a","b"],"nested":{"x":-1.5}}}}`
+	var m McpExecute
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalCBOR(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := m.MarshalCanonical()
+	have, err := got.MarshalCanonical()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(have) != string(want) {
+		t.Errorf("JSON→CBOR→JSON =\n%s\nwant\n%s", have, want)
+	}
+	if len(data) >= len(want) {
+		t.Errorf("CBOR is %d bytes, JSON %d", len(data), len(want))
+	}
+}
+
+func TestCBORNumericTypes(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"big":   json.Number("9007199254740993"),
+		"frac":  json.Number("1.5"),
+		"int":   7,
+		"float": float64(2),
+		"raw":   []byte{0, 1},
+	}}
+	data, err := m.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalCBOR(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"big":   int64(9007199254740993),
+		"frac":  1.5,
+		"int":   int64(7),
+		"float": float64(2),
+		"raw":   []byte{0, 1},
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("round trip = %#v, want %#v", got.Parameters, want)
+	}
+	if _, err := UnmarshalCBOR([]byte{0xa1}); err == nil {
+		t.Error("expected error for truncated input")
+	}
+}
+
+func TestCBORWrapperRoundTrip(t *testing.T) {
+	base := McpExecute{Tool: "deploy", Parameters: map[string]any{"replicas": int64(3)}}
+	deadline := time.Date(2025, 3, 1, 12, 0, 0, 500, time.UTC)
+	env := McpExecuteEnvelope{McpExecute: base, CorrelationID: "run-1", Deadline: &deadline, IdempotencyKey: "k"}
+	data, err := cbor.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotEnv McpExecuteEnvelope
+	if err := cbor.Unmarshal(data, &gotEnv); err != nil {
+		t.Fatal(err)
+	}
+	if gotEnv.Deadline == nil || !gotEnv.Deadline.Equal(deadline) {
+		t.Errorf("deadline = %v, want %v", gotEnv.Deadline, deadline)
+	}
+	gotEnv.Deadline = env.Deadline
+	if !reflect.DeepEqual(gotEnv, env) {
+		t.Errorf("envelope = %+v, want %+v", gotEnv, env)
+	}
+	if m, err := UnmarshalCBOR(data); err != nil || !reflect.DeepEqual(m, base) {
+		t.Errorf("envelope as McpExecute = %+v, %v", m, err)
+	}
+
+	guarded := McpExecuteGuarded{McpExecute: base, ApprovalRequired: true, ApprovalReason: "prod"}
+	data, err = cbor.Marshal(guarded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotGuarded McpExecuteGuarded
+	if err := cbor.Unmarshal(data, &gotGuarded); err != nil || !reflect.DeepEqual(gotGuarded, guarded) {
+		t.Errorf("guarded = %+v, %v", gotGuarded, err)
+	}
+
+	withPolicy := McpExecuteWithPolicy{McpExecute: base, Policy: McpExecutePolicy{TimeoutMs: 500, MaxRetries: 2, RetryBackoffMs: 100}}
+	data, err = cbor.Marshal(withPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPolicy McpExecuteWithPolicy
+	if err := cbor.Unmarshal(data, &gotPolicy); err != nil || !reflect.DeepEqual(gotPolicy, withPolicy) {
+		t.Errorf("with policy = %+v, %v", gotPolicy, err)
+	}
+}
@@ -0,0 +1,87 @@
+package schemas
+
+import "reflect"
+
+// Clone returns a deep copy of the request. Nested maps and slices in the
+// parameters are copied, so mutating the clone never affects the original;
+// values behind pointers are shared.
+func (m McpExecute) Clone() McpExecute {
+	out := m
+	out.Parameters = cloneObject(m.Parameters)
+	return out
+}
+
+func cloneObject(in map[string]any) map[string]any {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = cloneValue(v)
+	}
+	return out
+}
+
+func cloneValue(v any) any {
+	switch x := v.(type) {
+	case nil, string, bool, float64:
+		return v
+	case map[string]any:
+		return cloneObject(x)
+	case []any:
+		if x == nil {
+			return x
+		}
+		out := make([]any, len(x))
+		for i, item := range x {
+			out[i] = cloneValue(item)
+		}
+		return out
+	}
+	return cloneReflect(reflect.ValueOf(v)).Interface()
+}
+
+// cloneReflect deep-copies maps, slices and arrays of any type, preserving
+// the original types.
+func cloneReflect(rv reflect.Value) reflect.Value {
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), cloneElem(iter.Value(), rv.Type().Elem()))
+		}
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(cloneElem(rv.Index(i), rv.Type().Elem()))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(cloneElem(rv.Index(i), rv.Type().Elem()))
+		}
+		return out
+	}
+	return rv
+}
+
+// cloneElem deep-copies a container element, unwrapping interface values so
+// that their dynamic contents are copied too.
+func cloneElem(rv reflect.Value, typ reflect.Type) reflect.Value {
+	if rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Zero(typ)
+		}
+		return reflect.ValueOf(cloneValue(rv.Elem().Interface()))
+	}
+	return cloneReflect(rv)
+}
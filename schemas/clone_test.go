@@ -0,0 +1,39 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"config":  map[string]any{"regions": []any{"eu", map[string]any{"zone": "a"}}},
+		"tags":    []string{"x", "y"},
+		"labels":  map[string]string{"team": "core"},
+		"targets": []map[string]any{{"name": "api"}},
+	}}
+	orig := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"config":  map[string]any{"regions": []any{"eu", map[string]any{"zone": "a"}}},
+		"tags":    []string{"x", "y"},
+		"labels":  map[string]string{"team": "core"},
+		"targets": []map[string]any{{"name": "api"}},
+	}}
+
+	c := m.Clone()
+	if !reflect.DeepEqual(c, m) {
+		t.Fatalf("clone differs from original: %#v", c)
+	}
+
+	c.Tool = "other"
+	c.Parameters["new"] = 1
+	cfg := c.Parameters["config"].(map[string]any)
+	cfg["regions"].([]any)[0] = "us"
+	cfg["regions"].([]any)[1].(map[string]any)["zone"] = "b"
+	c.Parameters["tags"].([]string)[0] = "z"
+	c.Parameters["labels"].(map[string]string)["team"] = "infra"
+	c.Parameters["targets"].([]map[string]any)[0]["name"] = "web"
+
+	if !reflect.DeepEqual(m, orig) {
+		t.Errorf("mutating the clone changed the original:\n%#v", m.Parameters)
+	}
+}
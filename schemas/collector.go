@@ -0,0 +1,98 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ResultCollector gathers the results of a concurrently executed batch in
+// their original order. It is safe for concurrent use.
+type ResultCollector struct {
+	mu       sync.Mutex
+	results  []McpResult
+	received []bool
+	pending  int
+	done     chan struct{}
+}
+
+// NewResultCollector returns a collector expecting n results, indexed 0 to n-1.
+func NewResultCollector(n int) *ResultCollector {
+	c := &ResultCollector{
+		results:  make([]McpResult, n),
+		received: make([]bool, n),
+		pending:  n,
+		done:     make(chan struct{}),
+	}
+	if n == 0 {
+		close(c.done)
+	}
+	return c
+}
+
+// Add records the result for the execution at index. Adding an index twice
+// replaces the earlier result. An index out of range is reported as a
+// ValidationError and nothing is recorded.
+func (c *ResultCollector) Add(index int, r McpResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if index < 0 || index >= len(c.results) {
+		return &ValidationError{Field: "index", Message: fmt.Sprintf("%d out of range [0, %d)", index, len(c.results))}
+	}
+	c.results[index] = r
+	if c.received[index] {
+		return nil
+	}
+	c.received[index] = true
+	c.pending--
+	if c.pending == 0 {
+		close(c.done)
+	}
+	return nil
+}
+
+// Wait blocks until every expected result has been added and returns them
+// ordered by index.
+func (c *ResultCollector) Wait() McpBatchResult {
+	<-c.done
+	return c.snapshot()
+}
+
+// WaitCtx is like Wait but returns early when ctx is done. The partial
+// result still has one entry per index; entries not yet added are zero
+// McpResults, and the error is ctx.Err().
+func (c *ResultCollector) WaitCtx(ctx context.Context) (McpBatchResult, error) {
+	select {
+	case <-c.done:
+		return c.snapshot(), nil
+	case <-ctx.Done():
+		return c.snapshot(), ctx.Err()
+	}
+}
+
+// Received reports whether the result for index has been added.
+func (c *ResultCollector) Received(index int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return index >= 0 && index < len(c.received) && c.received[index]
+}
+
+// fillMissing returns the results with every index not yet added replaced
+// by missing(index).
+func (c *ResultCollector) fillMissing(missing func(index int) McpResult) McpBatchResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := McpBatchResult{Results: append([]McpResult(nil), c.results...)}
+	for i, ok := range c.received {
+		if !ok {
+			out.Results[i] = missing(i)
+		}
+	}
+	return out
+}
+
+func (c *ResultCollector) snapshot() McpBatchResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return McpBatchResult{Results: append([]McpResult(nil), c.results...)}
+}
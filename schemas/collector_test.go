@@ -0,0 +1,70 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResultCollectorWait(t *testing.T) {
+	const n = 20
+	c := NewResultCollector(n)
+	var wg sync.WaitGroup
+	for i := n - 1; i >= 0; i-- {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Add(i, McpResult{Tool: fmt.Sprintf("t%d", i)})
+		}(i)
+	}
+	got := c.Wait()
+	wg.Wait()
+	if len(got.Results) != n {
+		t.Fatalf("got %d results, want %d", len(got.Results), n)
+	}
+	for i, r := range got.Results {
+		if want := fmt.Sprintf("t%d", i); r.Tool != want {
+			t.Errorf("Results[%d].Tool = %q, want %q", i, r.Tool, want)
+		}
+	}
+}
+
+func TestResultCollectorWaitCtx(t *testing.T) {
+	c := NewResultCollector(3)
+	c.Add(1, McpResult{Tool: "b"})
+	c.Add(1, McpResult{Tool: "b2"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	got, err := c.WaitCtx(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if len(got.Results) != 3 || got.Results[1].Tool != "b2" || got.Results[0].Tool != "" {
+		t.Errorf("partial results = %+v", got.Results)
+	}
+	if !c.Received(1) || c.Received(0) {
+		t.Error("Received reports the wrong indexes")
+	}
+
+	c.Add(0, McpResult{Tool: "a"})
+	c.Add(2, McpResult{Tool: "c"})
+	if _, err := c.WaitCtx(context.Background()); err != nil {
+		t.Errorf("WaitCtx after all results: %v", err)
+	}
+	var verr *ValidationError
+	for _, i := range []int{-1, 3} {
+		if err := c.Add(i, McpResult{Tool: "x"}); !errors.As(err, &verr) || verr.Field != "index" {
+			t.Errorf("Add(%d) = %v, want a ValidationError", i, err)
+		}
+	}
+	if got := c.Wait(); len(got.Results) != 3 || got.Results[2].Tool != "c" {
+		t.Errorf("results after bad Add = %+v", got.Results)
+	}
+	if got := NewResultCollector(0).Wait(); len(got.Results) != 0 {
+		t.Errorf("empty collector returned %d results", len(got.Results))
+	}
+}
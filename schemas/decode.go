@@ -0,0 +1,50 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxDecodeBytes is the input size limit applied by DecodeStrict.
+const DefaultMaxDecodeBytes = 1 << 20
+
+// DecodeOptions controls DecodeStrictWithOptions.
+type DecodeOptions struct {
+	// MaxBytes limits the input size; zero means DefaultMaxDecodeBytes.
+	MaxBytes int
+}
+
+// DecodeStrict decodes an execution request from untrusted JSON input. It
+// rejects inputs larger than DefaultMaxDecodeBytes, unknown top-level
+// fields, trailing data after the JSON value and requests that fail
+// Validate.
+func DecodeStrict(data []byte) (McpExecute, error) {
+	return DecodeStrictWithOptions(data, DecodeOptions{})
+}
+
+// DecodeStrictWithOptions is like DecodeStrict but honours opts.
+func DecodeStrictWithOptions(data []byte, opts DecodeOptions) (McpExecute, error) {
+	limit := opts.MaxBytes
+	if limit <= 0 {
+		limit = DefaultMaxDecodeBytes
+	}
+	if len(data) > limit {
+		return McpExecute{}, &LimitError{Limit: "maxBytes", Max: limit, Actual: len(data)}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var m McpExecute
+	if err := dec.Decode(&m); err != nil {
+		return McpExecute{}, fmt.Errorf("decode McpExecute: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return McpExecute{}, errors.New("decode McpExecute: unexpected data after JSON value")
+	}
+	if err := m.Validate(); err != nil {
+		return McpExecute{}, err
+	}
+	return m, nil
+}
@@ -0,0 +1,60 @@
+package schemas
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	m, err := DecodeStrict([]byte(` {"tool":"search","parameters":{"q":"go","extra":{"nested":true}}} `))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Tool != "search" || m.Parameters["q"] != "go" {
+		t.Errorf("decoded %+v", m)
+	}
+
+	bad := []string{
+		``,
+		`null`,
+		`{"tool":"search","unknown":1}`,
+		`{"tool":"search"} {"tool":"again"}`,
+		`{"tool":"search"}x`,
+		`{"tool":""}`,
+		`{"tool":"search","parameters":[]}`,
+	}
+	for _, in := range bad {
+		if _, err := DecodeStrict([]byte(in)); err == nil {
+			t.Errorf("DecodeStrict(%q) succeeded", in)
+		}
+	}
+
+	big := `{"tool":"t","parameters":{"blob":"` + strings.Repeat("a", 64) + `"}}`
+	if _, err := DecodeStrictWithOptions([]byte(big), DecodeOptions{MaxBytes: 32}); err == nil {
+		t.Error("oversized input accepted")
+	}
+}
+
+func FuzzDecodeStrict(f *testing.F) {
+	for _, seed := range []string{
+		`{"tool":"search","parameters":{"q":"go"}}`,
+		`{"tool":"t","parameters":{"a":[1,2,{"b":null}]}}`,
+		`{"tool":"t"} trailing`,
+		`{`,
+		`[]`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		m, err := DecodeStrict(data)
+		if err != nil {
+			return
+		}
+		if err := m.Validate(); err != nil {
+			t.Fatalf("DecodeStrict returned invalid request: %v", err)
+		}
+		if _, err := m.MarshalCanonical(); err != nil {
+			t.Fatalf("decoded request cannot be re-encoded: %v", err)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package schemas
+
+// WithDefaults returns a copy of the request in which every top-level key of
+// defaults that is absent from the parameters is filled in. Keys already
+// present in the parameters are kept as they are.
+func (m McpExecute) WithDefaults(defaults map[string]any) McpExecute {
+	out := m.Clone()
+	out.Parameters = mergeDefaults(out.Parameters, defaults, false)
+	return out
+}
+
+// WithDefaultsDeep is like WithDefaults but also merges nested objects, so a
+// default for "config.timeout" is applied even when "config" is present.
+func (m McpExecute) WithDefaultsDeep(defaults map[string]any) McpExecute {
+	out := m.Clone()
+	out.Parameters = mergeDefaults(out.Parameters, defaults, true)
+	return out
+}
+
+// mergeDefaults fills params in place with copies of the missing defaults.
+func mergeDefaults(params, defaults map[string]any, deep bool) map[string]any {
+	if len(defaults) == 0 {
+		return params
+	}
+	if params == nil {
+		params = make(map[string]any, len(defaults))
+	}
+	for k, def := range defaults {
+		cur, ok := params[k]
+		if !ok {
+			params[k] = cloneValue(def)
+			continue
+		}
+		if !deep {
+			continue
+		}
+		curObj, curIsObj := cur.(map[string]any)
+		defObj, defIsObj := def.(map[string]any)
+		if curIsObj && defIsObj {
+			mergeDefaults(curObj, defObj, true)
+		}
+	}
+	return params
+}
@@ -0,0 +1,37 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithDefaults(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"branch": "dev",
+		"config": map[string]any{"retries": 1},
+	}}
+	defaults := map[string]any{
+		"branch": "main",
+		"draft":  true,
+		"config": map[string]any{"retries": 3, "timeout": 30},
+	}
+
+	shallow := m.WithDefaults(defaults)
+	want := map[string]any{"branch": "dev", "draft": true, "config": map[string]any{"retries": 1}}
+	if !reflect.DeepEqual(shallow.Parameters, want) {
+		t.Errorf("WithDefaults = %#v, want %#v", shallow.Parameters, want)
+	}
+
+	deep := m.WithDefaultsDeep(defaults)
+	want = map[string]any{"branch": "dev", "draft": true, "config": map[string]any{"retries": 1, "timeout": 30}}
+	if !reflect.DeepEqual(deep.Parameters, want) {
+		t.Errorf("WithDefaultsDeep = %#v, want %#v", deep.Parameters, want)
+	}
+
+	if _, ok := m.Parameters["draft"]; ok {
+		t.Error("WithDefaults mutated the original request")
+	}
+	if _, ok := m.Parameters["config"].(map[string]any)["timeout"]; ok {
+		t.Error("WithDefaultsDeep mutated the original nested parameters")
+	}
+}
@@ -0,0 +1,67 @@
+package schemas
+
+import "sort"
+
+// DiffKind classifies a difference between two execution requests.
+type DiffKind string
+
+const (
+	// DiffTool indicates the tool names differ.
+	DiffTool DiffKind = "tool"
+	// DiffOnlyInA indicates a parameter present only in the first request.
+	DiffOnlyInA DiffKind = "onlyInA"
+	// DiffOnlyInB indicates a parameter present only in the second request.
+	DiffOnlyInB DiffKind = "onlyInB"
+	// DiffChanged indicates a parameter whose value differs.
+	DiffChanged DiffKind = "changed"
+)
+
+// ParamDiff describes a single difference between two execution requests.
+type ParamDiff struct {
+	Kind DiffKind `json:"kind" doc:"The kind of difference."`
+	Path string   `json:"path,omitempty" doc:"The dotted path of the parameter, empty for tool changes."`
+	Old  any      `json:"old,omitempty" doc:"The value in the first request."`
+	New  any      `json:"new,omitempty" doc:"The value in the second request."`
+}
+
+// Diff returns the differences between a and b, ordered by path. Nested
+// objects are compared key by key and reported with dotted paths such as
+// "config.timeout"; any other values, including arrays, are compared as a
+// whole. Numbers compare equal when their values are equal regardless of Go
+// type.
+func Diff(a, b McpExecute) []ParamDiff {
+	var diffs []ParamDiff
+	if a.Tool != b.Tool {
+		diffs = append(diffs, ParamDiff{Kind: DiffTool, Old: a.Tool, New: b.Tool})
+	}
+	return diffObjects(diffs, "", a.Parameters, b.Parameters)
+}
+
+func diffObjects(diffs []ParamDiff, path string, a, b map[string]any) []ParamDiff {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		av, inA := a[k]
+		bv, inB := b[k]
+		p := joinPath(path, k)
+		switch {
+		case !inB:
+			diffs = append(diffs, ParamDiff{Kind: DiffOnlyInA, Path: p, Old: av})
+		case !inA:
+			diffs = append(diffs, ParamDiff{Kind: DiffOnlyInB, Path: p, New: bv})
+		case jsonKind(av) == kindObject && jsonKind(bv) == kindObject:
+			diffs = diffObjects(diffs, p, toObject(av), toObject(bv))
+		case !valuesEqual(av, bv):
+			diffs = append(diffs, ParamDiff{Kind: DiffChanged, Path: p, Old: av, New: bv})
+		}
+	}
+	return diffs
+}
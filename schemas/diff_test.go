@@ -0,0 +1,34 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service": "api",
+		"count":   float64(2),
+		"old":     true,
+		"config":  map[string]any{"timeout": float64(30), "region": "eu"},
+	}}
+	b := McpExecute{Tool: "deploy_v2", Parameters: map[string]any{
+		"service": "api",
+		"count":   2,
+		"new":     "x",
+		"config":  map[string]any{"timeout": float64(60), "region": "eu"},
+	}}
+	got := Diff(a, b)
+	want := []ParamDiff{
+		{Kind: DiffTool, Old: "deploy", New: "deploy_v2"},
+		{Kind: DiffChanged, Path: "config.timeout", Old: float64(30), New: float64(60)},
+		{Kind: DiffOnlyInB, Path: "new", New: "x"},
+		{Kind: DiffOnlyInA, Path: "old", Old: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff =\n%#v\nwant\n%#v", got, want)
+	}
+	if d := Diff(a, a); len(d) != 0 {
+		t.Errorf("Diff(a, a) = %v, want none", d)
+	}
+}
@@ -0,0 +1,63 @@
+package schemas
+
+// DryRunStep describes what a workflow step would do.
+type DryRunStep struct {
+	Name        string     `json:"name" doc:"The name of the step."`
+	Tool        string     `json:"tool" doc:"The registered tool the step resolves to, or the requested name if it is unknown."`
+	SideEffect  SideEffect `json:"sideEffect,omitempty" doc:"The side-effect level declared by the tool."`
+	Known       bool       `json:"known" doc:"Whether the tool is registered."`
+	Destructive bool       `json:"destructive,omitempty" doc:"Whether the tool is declared destructive."`
+	Problem     string     `json:"problem,omitempty" doc:"Why the tool could not be resolved, if it is unknown."`
+}
+
+// DryRunReport previews a workflow run without executing anything.
+type DryRunReport struct {
+	Steps       []DryRunStep `json:"steps" doc:"The steps in the order they would run."`
+	Destructive []string     `json:"destructive,omitempty" doc:"The steps whose tools are declared destructive."`
+	Unknown     []string     `json:"unknown,omitempty" doc:"The steps whose tools are not registered or are ambiguous."`
+	Undeclared  []string     `json:"undeclared,omitempty" doc:"The steps whose registered tools declare no side effect."`
+}
+
+// Safe reports whether every step resolves to a tool declared read-only or
+// mutating.
+func (r DryRunReport) Safe() bool {
+	return len(r.Destructive) == 0 && len(r.Unknown) == 0 && len(r.Undeclared) == 0
+}
+
+// DryRun resolves every step's tool against reg, as ToolRegistry.Lookup
+// does, and reports its declared side effect in topological order. Unknown
+// and ambiguous tools, and tools without a declared side effect, are listed
+// separately rather than treated as read-only. It returns an error only if
+// the workflow is invalid.
+func (w Workflow) DryRun(reg ToolRegistry) (DryRunReport, error) {
+	order, err := w.TopologicalOrder()
+	if err != nil {
+		return DryRunReport{}, err
+	}
+	byName := make(map[string]WorkflowStep, len(w.Steps))
+	for _, s := range w.Steps {
+		byName[s.Name] = s
+	}
+	report := DryRunReport{Steps: make([]DryRunStep, 0, len(order))}
+	for _, name := range order {
+		s := byName[name]
+		step := DryRunStep{Name: name, Tool: s.Execute.Tool}
+		def, err := reg.Lookup(s.Execute.Tool)
+		switch {
+		case err != nil:
+			step.Problem = err.Error()
+			report.Unknown = append(report.Unknown, name)
+		default:
+			step.Tool, step.SideEffect, step.Known = def.Name, def.SideEffect, true
+			switch def.SideEffect {
+			case Destructive:
+				step.Destructive = true
+				report.Destructive = append(report.Destructive, name)
+			case "":
+				report.Undeclared = append(report.Undeclared, name)
+			}
+		}
+		report.Steps = append(report.Steps, step)
+	}
+	return report, nil
+}
@@ -0,0 +1,65 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowDryRun(t *testing.T) {
+	reg := NewToolRegistry()
+	for _, def := range []ToolDefinition{
+		{Name: "tool_build", SideEffect: Mutating},
+		{Name: "tool_test", SideEffect: ReadOnly},
+		{Name: "tool_lint"},
+	} {
+		if err := reg.Register(def); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := reg.RegisterNamespaced("prod", ToolDefinition{Name: "tool_deploy", SideEffect: Destructive}); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Register(ToolDefinition{Name: "bad", SideEffect: "explosive"}); err == nil {
+		t.Error("expected unknown side effect to be rejected")
+	}
+
+	w := Workflow{Steps: []WorkflowStep{
+		step("deploy", "build", "test"),
+		step("build"),
+		step("test", "build"),
+		step("lint"),
+		step("notify", "deploy"),
+	}}
+	report, err := w.DryRun(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range report.Steps {
+		names = append(names, s.Name)
+	}
+	if want := []string{"build", "test", "deploy", "lint", "notify"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("step order = %v, want %v", names, want)
+	}
+	deploy := report.Steps[2]
+	if deploy.Tool != "prod.tool_deploy" || deploy.SideEffect != Destructive || !deploy.Destructive || !deploy.Known {
+		t.Errorf("deploy step = %+v", deploy)
+	}
+	notify := report.Steps[4]
+	if notify.Known || notify.SideEffect != "" || notify.Problem == "" {
+		t.Errorf("unknown step = %+v", notify)
+	}
+	if !reflect.DeepEqual(report.Destructive, []string{"deploy"}) ||
+		!reflect.DeepEqual(report.Unknown, []string{"notify"}) ||
+		!reflect.DeepEqual(report.Undeclared, []string{"lint"}) || report.Safe() {
+		t.Errorf("report = %+v", report)
+	}
+
+	safe, err := Workflow{Steps: []WorkflowStep{step("build"), step("test", "build")}}.DryRun(reg)
+	if err != nil || !safe.Safe() {
+		t.Errorf("safe workflow report = %+v, %v", safe, err)
+	}
+	if _, err := (Workflow{Steps: []WorkflowStep{step("a", "missing")}}).DryRun(reg); err == nil {
+		t.Error("expected invalid workflow to fail")
+	}
+}
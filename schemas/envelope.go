@@ -0,0 +1,47 @@
+package schemas
+
+import "time"
+
+// McpExecuteEnvelope defines an MCP tool execution together with the
+// cross-cutting metadata an orchestrator propagates to downstream tools.
+type McpExecuteEnvelope struct {
+	McpExecute
+	CorrelationID  string     `json:"correlationId,omitempty" doc:"An identifier shared by all executions of a workflow run."`
+	Deadline       *time.Time `json:"deadline,omitempty" doc:"The time after which the execution should be abandoned."`
+	IdempotencyKey string     `json:"idempotencyKey,omitempty" doc:"A client-chosen key identifying retries of the same logical execution."`
+}
+
+// EnvelopeOptions controls McpExecuteEnvelope validation.
+type EnvelopeOptions struct {
+	// StrictDeadline rejects envelopes whose deadline has already passed.
+	StrictDeadline bool
+	// Now returns the current time; it defaults to time.Now.
+	Now func() time.Time
+}
+
+// Expired reports whether the deadline, if any, has passed at now.
+func (e McpExecuteEnvelope) Expired(now time.Time) bool {
+	return e.Deadline != nil && !now.Before(*e.Deadline)
+}
+
+// Validate checks that the wrapped execution request is well-formed.
+func (e McpExecuteEnvelope) Validate() error {
+	return e.ValidateWithOptions(EnvelopeOptions{})
+}
+
+// ValidateWithOptions is like Validate but honours opts.
+func (e McpExecuteEnvelope) ValidateWithOptions(opts EnvelopeOptions) error {
+	if err := e.McpExecute.Validate(); err != nil {
+		return err
+	}
+	if opts.StrictDeadline && e.Deadline != nil {
+		now := time.Now
+		if opts.Now != nil {
+			now = opts.Now
+		}
+		if e.Expired(now()) {
+			return &ValidationError{Field: "deadline", Message: "already passed"}
+		}
+	}
+	return nil
+}
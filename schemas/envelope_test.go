@@ -0,0 +1,33 @@
+package schemas
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMcpExecuteEnvelope(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+	clock := func() time.Time { return now }
+
+	e := McpExecuteEnvelope{McpExecute: McpExecute{Tool: "t"}, CorrelationID: "run-1"}
+	if e.Expired(now) {
+		t.Error("envelope without deadline expired")
+	}
+
+	e.Deadline = &future
+	if e.Expired(now) || !e.Expired(future) {
+		t.Error("Expired disagrees with deadline")
+	}
+	if err := e.ValidateWithOptions(EnvelopeOptions{StrictDeadline: true, Now: clock}); err != nil {
+		t.Errorf("future deadline rejected: %v", err)
+	}
+
+	e.Deadline = &past
+	if err := e.Validate(); err != nil {
+		t.Errorf("past deadline rejected without StrictDeadline: %v", err)
+	}
+	if err := e.ValidateWithOptions(EnvelopeOptions{StrictDeadline: true, Now: clock}); err == nil {
+		t.Error("past deadline accepted with StrictDeadline")
+	}
+}
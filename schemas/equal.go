@@ -0,0 +1,32 @@
+package schemas
+
+// Equal reports whether m and other describe the same call. Tool names are
+// compared exactly and schema versions after defaulting, as by Version.
+// Parameters are compared structurally:
+//
+//   - numbers are equal when their values are, whatever their Go types and
+//     whether or not they are behind pointers, so int(1), float64(1.0),
+//     json.Number("1") and a *int pointing at 1 are all equal;
+//   - objects, including maps with string keys of any value type, are equal
+//     when they have the same keys with equal values, and a nil parameter
+//     map equals an empty one;
+//   - arrays and slices of any element type are equal when they have the
+//     same length and equal elements in order;
+//   - strings and booleans are equal when their values are, including
+//     named types and pointers; nulls, including nil pointers, are equal to
+//     each other; and values of different JSON kinds are never equal.
+//
+// Unlike Fingerprint, Equal does not require the parameters to be
+// encodable as JSON.
+func (m McpExecute) Equal(other McpExecute) bool {
+	if m.Tool != other.Tool || m.Version() != other.Version() || len(m.Parameters) != len(other.Parameters) {
+		return false
+	}
+	for k, v := range m.Parameters {
+		ov, ok := other.Parameters[k]
+		if !ok || !valuesEqual(v, ov) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	base := McpExecute{Tool: "search", Parameters: map[string]any{
+		"limit":   float64(10),
+		"filters": map[string]any{"tags": []any{"a", "b"}, "minScore": 0.5},
+		"exact":   true,
+		"cursor":  nil,
+	}}
+	tests := []struct {
+		name  string
+		other McpExecute
+		want  bool
+	}{
+		{"identical", base.Clone(), true},
+		{"numeric types", McpExecute{Tool: "search", Parameters: map[string]any{
+			"limit":   10,
+			"filters": map[string]any{"tags": []string{"a", "b"}, "minScore": json.Number("0.5")},
+			"exact":   true,
+			"cursor":  nil,
+		}}, true},
+		{"explicit version", McpExecute{Tool: "search", Parameters: base.Clone().Parameters, SchemaVersion: CurrentSchemaVersion}, true},
+		{"different tool", McpExecute{Tool: "find", Parameters: base.Parameters}, false},
+		{"different number", McpExecute{Tool: "search", Parameters: map[string]any{
+			"limit": 10.5, "filters": base.Parameters["filters"], "exact": true, "cursor": nil,
+		}}, false},
+		{"missing key", McpExecute{Tool: "search", Parameters: map[string]any{
+			"limit": 10, "filters": base.Parameters["filters"], "exact": true,
+		}}, false},
+		{"array order", McpExecute{Tool: "search", Parameters: map[string]any{
+			"limit": 10, "filters": map[string]any{"tags": []any{"b", "a"}, "minScore": 0.5}, "exact": true, "cursor": nil,
+		}}, false},
+		{"kind mismatch", McpExecute{Tool: "search", Parameters: map[string]any{
+			"limit": "10", "filters": base.Parameters["filters"], "exact": true, "cursor": nil,
+		}}, false},
+	}
+	for _, tt := range tests {
+		if got := base.Equal(tt.other); got != tt.want {
+			t.Errorf("%s: Equal = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.other.Equal(base); got != tt.want {
+			t.Errorf("%s: reversed Equal = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if !(McpExecute{Tool: "t"}).Equal(McpExecute{Tool: "t", Parameters: map[string]any{}}) {
+		t.Error("nil and empty parameters should be equal")
+	}
+}
+
+func TestEqualPointers(t *testing.T) {
+	one, two, oneF := 1, 2, 1.0
+	s1, s2 := "a", "b"
+	yes := true
+	var nilInt *int
+	params := func(n any, s any, b any) McpExecute {
+		return McpExecute{Tool: "t", Parameters: map[string]any{"n": n, "s": s, "b": b}}
+	}
+	tests := []struct {
+		name string
+		a, b McpExecute
+		want bool
+	}{
+		{"same pointees", params(&one, &s1, &yes), params(1, "a", true), true},
+		{"int and float pointers", params(&one, "a", true), params(&oneF, "a", true), true},
+		{"different numbers", params(&one, "a", true), params(&two, "a", true), false},
+		{"different strings", params(1, &s1, true), params(1, &s2, true), false},
+		{"nil pointer is null", params(nilInt, "a", true), params(nil, "a", true), true},
+		{"nil pointer is not zero", params(nilInt, "a", true), params(0, "a", true), false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%s: Equal = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package schemas
+
+import "fmt"
+
+// McpErrorCode classifies why an MCP tool execution failed.
+type McpErrorCode string
+
+const (
+	// Unknown is reported for failures that carry no classification.
+	Unknown McpErrorCode = "unknown"
+	// ToolNotFound indicates the requested tool does not exist.
+	ToolNotFound McpErrorCode = "tool_not_found"
+	// InvalidParams indicates the parameters were rejected by the tool.
+	InvalidParams McpErrorCode = "invalid_params"
+	// Timeout indicates the execution did not complete in time.
+	Timeout McpErrorCode = "timeout"
+	// ToolInternal indicates the tool failed while executing.
+	ToolInternal McpErrorCode = "tool_internal"
+	// Cancelled indicates the execution was cancelled before completing.
+	Cancelled McpErrorCode = "cancelled"
+)
+
+// McpError defines a structured MCP tool execution failure.
+type McpError struct {
+	Code    McpErrorCode   `json:"code" doc:"The classification of the failure."`
+	Message string         `json:"message" doc:"A human-readable description of the failure."`
+	Details map[string]any `json:"details,omitempty" doc:"Arbitrary additional information about the failure."`
+}
+
+func (e *McpError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// ErrorResult returns a failed result for tool carrying err both as a
+// structured error and as the plain error message.
+func ErrorResult(tool string, err *McpError) McpResult {
+	return McpResult{Tool: tool, Error: err.Message, ErrorDetail: err}
+}
+
+// StructuredError returns the failure of the result, if any. Results that
+// only carry a plain error message are reported with code Unknown.
+func (r McpResult) StructuredError() (*McpError, bool) {
+	if r.ErrorDetail != nil {
+		return r.ErrorDetail, true
+	}
+	if r.Error != "" {
+		return &McpError{Code: Unknown, Message: r.Error}, true
+	}
+	return nil, false
+}
+
+// Standard JSON-RPC 2.0 error codes, plus the request-timeout code used by
+// MCP implementations.
+const (
+	jsonrpcParseError     = -32700
+	jsonrpcInvalidRequest = -32600
+	jsonrpcMethodNotFound = -32601
+	jsonrpcInvalidParams  = -32602
+	jsonrpcInternalError  = -32603
+	jsonrpcRequestTimeout = -32001
+	jsonrpcServerErrorMin = -32099
+	jsonrpcServerErrorMax = -32000
+)
+
+// McpErrorFromJSONRPC converts a JSON-RPC error object into an McpError.
+// Method not found maps to ToolNotFound; invalid params, invalid request
+// and parse errors map to InvalidParams; the MCP request-timeout code
+// -32001 maps to Timeout; internal and other implementation-defined server
+// errors (-32099 to -32000) map to ToolInternal; any other code maps to
+// Unknown. The original code is kept in Details["jsonrpcCode"] and, if
+// present, the data in Details["jsonrpcData"].
+func McpErrorFromJSONRPC(code int, message string, data any) McpError {
+	e := McpError{Code: Unknown, Message: message, Details: map[string]any{"jsonrpcCode": code}}
+	if data != nil {
+		e.Details["jsonrpcData"] = data
+	}
+	switch {
+	case code == jsonrpcMethodNotFound:
+		e.Code = ToolNotFound
+	case code == jsonrpcInvalidParams, code == jsonrpcInvalidRequest, code == jsonrpcParseError:
+		e.Code = InvalidParams
+	case code == jsonrpcRequestTimeout:
+		e.Code = Timeout
+	case code == jsonrpcInternalError, code >= jsonrpcServerErrorMin && code <= jsonrpcServerErrorMax:
+		e.Code = ToolInternal
+	}
+	if e.Message == "" {
+		e.Message = fmt.Sprintf("JSON-RPC error %d", code)
+	}
+	return e
+}
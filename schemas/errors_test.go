@@ -0,0 +1,58 @@
+package schemas
+
+import "testing"
+
+func TestStructuredError(t *testing.T) {
+	if _, ok := (McpResult{Tool: "t"}).StructuredError(); ok {
+		t.Error("successful result reported an error")
+	}
+
+	r := ErrorResult("t", &McpError{Code: Timeout, Message: "deadline exceeded"})
+	if !r.IsError() {
+		t.Error("IsError() = false for structured error")
+	}
+	if e, ok := r.StructuredError(); !ok || e.Code != Timeout {
+		t.Errorf("StructuredError() = %v, %v", e, ok)
+	}
+
+	legacy := McpResult{Tool: "t", Error: "boom"}
+	if e, ok := legacy.StructuredError(); !ok || e.Code != Unknown || e.Message != "boom" {
+		t.Errorf("StructuredError() = %v, %v", e, ok)
+	}
+
+	onlyDetail := McpResult{Tool: "t", ErrorDetail: &McpError{Code: Cancelled}}
+	if !onlyDetail.IsError() {
+		t.Error("IsError() = false when only ErrorDetail is set")
+	}
+}
+
+func TestMcpErrorFromJSONRPC(t *testing.T) {
+	tests := []struct {
+		code int
+		want McpErrorCode
+	}{
+		{-32601, ToolNotFound},
+		{-32602, InvalidParams},
+		{-32600, InvalidParams},
+		{-32700, InvalidParams},
+		{-32603, ToolInternal},
+		{-32050, ToolInternal},
+		{-32001, Timeout},
+		{42, Unknown},
+	}
+	for _, tt := range tests {
+		e := McpErrorFromJSONRPC(tt.code, "failed", nil)
+		if e.Code != tt.want || e.Message != "failed" || e.Details["jsonrpcCode"] != tt.code {
+			t.Errorf("McpErrorFromJSONRPC(%d) = %+v, want code %s", tt.code, e, tt.want)
+		}
+		if _, ok := e.Details["jsonrpcData"]; ok {
+			t.Errorf("McpErrorFromJSONRPC(%d) recorded nil data", tt.code)
+		}
+	}
+
+	data := map[string]any{"field": "query"}
+	e := McpErrorFromJSONRPC(-32602, "", data)
+	if e.Message != "JSON-RPC error -32602" || e.Details["jsonrpcData"].(map[string]any)["field"] != "query" {
+		t.Errorf("McpErrorFromJSONRPC with data = %+v", e)
+	}
+}
@@ -0,0 +1,111 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Executor runs MCP tool executions.
+type Executor interface {
+	Execute(ctx context.Context, m McpExecute) (McpResult, error)
+}
+
+// ExecutorFunc adapts an ordinary function to the Executor interface.
+type ExecutorFunc func(ctx context.Context, m McpExecute) (McpResult, error)
+
+// Execute calls f(ctx, m).
+func (f ExecutorFunc) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	return f(ctx, m)
+}
+
+// ErrUnexpectedCall is returned by MockExecutor for calls it has no response for.
+var ErrUnexpectedCall = errors.New("unexpected MCP tool call")
+
+type mockResponse struct {
+	result McpResult
+	err    error
+}
+
+// MockExecutor is an Executor that serves canned responses. Calls are
+// matched first by fingerprint, then by tool name alone. The zero value is
+// ready to use and safe for concurrent use.
+type MockExecutor struct {
+	mu     sync.Mutex
+	exact  map[string]mockResponse
+	byTool map[string]mockResponse
+	calls  []McpExecute
+}
+
+// NewMockExecutor returns a MockExecutor with no registered responses.
+func NewMockExecutor() *MockExecutor {
+	return &MockExecutor{}
+}
+
+// On registers the result returned for calls equal to m. If m's parameters
+// cannot be encoded, so m has no fingerprint, the result is registered for
+// its tool as if by OnTool.
+func (e *MockExecutor) On(m McpExecute, r McpResult) *MockExecutor {
+	return e.register(m.Fingerprint(), m.Tool, mockResponse{result: r})
+}
+
+// OnError registers the error returned for calls equal to m, falling back
+// to m's tool like On.
+func (e *MockExecutor) OnError(m McpExecute, err error) *MockExecutor {
+	return e.register(m.Fingerprint(), m.Tool, mockResponse{err: err})
+}
+
+// OnTool registers the result returned for any call to tool that has no
+// exact registration.
+func (e *MockExecutor) OnTool(tool string, r McpResult) *MockExecutor {
+	return e.register("", tool, mockResponse{result: r})
+}
+
+// register stores resp under fingerprint, or under tool if fingerprint is
+// empty.
+func (e *MockExecutor) register(fingerprint, tool string, resp mockResponse) *MockExecutor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if fingerprint != "" {
+		if e.exact == nil {
+			e.exact = make(map[string]mockResponse)
+		}
+		e.exact[fingerprint] = resp
+	} else {
+		if e.byTool == nil {
+			e.byTool = make(map[string]mockResponse)
+		}
+		e.byTool[tool] = resp
+	}
+	return e
+}
+
+// Execute records the call and returns the matching canned response, or an
+// error wrapping ErrUnexpectedCall if there is none.
+func (e *MockExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	fingerprint := m.Fingerprint()
+	e.mu.Lock()
+	e.calls = append(e.calls, m.Clone())
+	resp, ok := e.exact[fingerprint]
+	if !ok {
+		resp, ok = e.byTool[m.Tool]
+	}
+	e.mu.Unlock()
+	if !ok {
+		return McpResult{}, fmt.Errorf("%w: %s", ErrUnexpectedCall, m.Tool)
+	}
+	if err := ctx.Err(); err != nil {
+		return McpResult{}, err
+	}
+	return resp.result, resp.err
+}
+
+// Calls returns the calls received so far, in order.
+func (e *MockExecutor) Calls() []McpExecute {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	calls := make([]McpExecute, len(e.calls))
+	copy(calls, e.calls)
+	return calls
+}
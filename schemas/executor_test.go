@@ -0,0 +1,46 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMockExecutor(t *testing.T) {
+	ctx := context.Background()
+	call := McpExecute{Tool: "search", Parameters: map[string]any{"q": "go"}}
+	boom := errors.New("boom")
+	mock := NewMockExecutor().
+		On(call, McpResult{Tool: "search", Output: "exact"}).
+		OnTool("search", McpResult{Tool: "search", Output: "fallback"}).
+		OnError(McpExecute{Tool: "fail"}, boom)
+
+	if r, err := mock.Execute(ctx, McpExecute{Tool: "search", Parameters: map[string]any{"q": "go"}}); err != nil || r.Output != "exact" {
+		t.Errorf("exact match = %+v, %v", r, err)
+	}
+	if r, err := mock.Execute(ctx, McpExecute{Tool: "search", Parameters: map[string]any{"q": "rust"}}); err != nil || r.Output != "fallback" {
+		t.Errorf("tool match = %+v, %v", r, err)
+	}
+	if _, err := mock.Execute(ctx, McpExecute{Tool: "fail"}); !errors.Is(err, boom) {
+		t.Errorf("canned error = %v", err)
+	}
+	if _, err := mock.Execute(ctx, McpExecute{Tool: "other"}); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("unexpected call error = %v", err)
+	}
+
+	calls := mock.Calls()
+	if len(calls) != 4 || calls[3].Tool != "other" {
+		t.Errorf("Calls() = %+v", calls)
+	}
+}
+
+func TestMockExecutorUnencodableParams(t *testing.T) {
+	call := McpExecute{Tool: "stream", Parameters: map[string]any{"ch": make(chan int)}}
+	mock := NewMockExecutor().On(call, McpResult{Tool: "stream", Output: "ok"})
+	if r, err := mock.Execute(context.Background(), McpExecute{Tool: "stream"}); err != nil || r.Output != "ok" {
+		t.Errorf("unencodable registration = %+v, %v", r, err)
+	}
+	if _, err := mock.Execute(context.Background(), McpExecute{Tool: ""}); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("registration leaked to the empty tool: %v", err)
+	}
+}
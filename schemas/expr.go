@@ -0,0 +1,379 @@
+package schemas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The step condition language is a small boolean expression grammar over
+// the results of earlier steps:
+//
+//	or      = and { ("||" | "or") and }
+//	and     = unary { ("&&" | "and") unary }
+//	unary   = ("!" | "not") unary | compare
+//	compare = operand [ ("==" | "!=" | "<" | "<=" | ">" | ">=") operand ]
+//	operand = number | string | "true" | "false" | "null" | path | "(" or ")"
+//
+// A path such as step1.exitCode or step1.output.files names a field of a
+// step's McpResult: exitCode, durationMs, error, isError or output, with
+// further segments indexing into an object output. Paths that do not
+// resolve evaluate to null. Strings are quoted with ' or ".
+
+type exprKind int
+
+const (
+	exprLiteral exprKind = iota
+	exprPath
+	exprNot
+	exprAnd
+	exprOr
+	exprCompare
+)
+
+// expr is a node of a parsed condition.
+type expr struct {
+	kind  exprKind
+	value any
+	path  []string
+	op    string
+	left  *expr
+	right *expr
+}
+
+type exprToken struct {
+	kind string // "num", "str", "ident", "op", "(", ")"
+	text string
+	val  any
+}
+
+// parseExpr parses a step condition.
+func parseExpr(src string) (*expr, error) {
+	toks, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+	return e, nil
+}
+
+func lexExpr(src string) ([]exprToken, error) {
+	var toks []exprToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			toks = append(toks, exprToken{kind: string(c), text: string(c)})
+			i++
+		case c == '\'' || c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			raw := src[i+1 : j]
+			s := strings.NewReplacer(`\\`, `\`, `\'`, `'`, `\"`, `"`).Replace(raw)
+			toks = append(toks, exprToken{kind: "str", text: src[i : j+1], val: s})
+			i = j + 1
+		case strings.ContainsRune("=!<>&|", rune(c)):
+			op := string(c)
+			if i+1 < len(src) && isExprOp(src[i:i+2]) {
+				op = src[i : i+2]
+			}
+			if op == "=" || op == "&" || op == "|" {
+				return nil, fmt.Errorf("unknown operator %q at offset %d", op, i)
+			}
+			toks = append(toks, exprToken{kind: "op", text: op})
+			i += len(op)
+		case c == '-' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(src) && (src[j] == '.' || src[j] == 'e' || src[j] == 'E' || src[j] == '+' || src[j] == '-' || (src[j] >= '0' && src[j] <= '9')) {
+				if (src[j] == '+' || src[j] == '-') && src[j-1] != 'e' && src[j-1] != 'E' {
+					break
+				}
+				j++
+			}
+			f, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", src[i:j])
+			}
+			toks = append(toks, exprToken{kind: "num", text: src[i:j], val: f})
+			i = j
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || src[j] == '-' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			word := src[i:j]
+			switch word {
+			case "and":
+				toks = append(toks, exprToken{kind: "op", text: "&&"})
+			case "or":
+				toks = append(toks, exprToken{kind: "op", text: "||"})
+			case "not":
+				toks = append(toks, exprToken{kind: "op", text: "!"})
+			default:
+				toks = append(toks, exprToken{kind: "ident", text: word})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return toks, nil
+}
+
+func isExprOp(s string) bool {
+	switch s {
+	case "==", "!=", "<=", ">=", "&&", "||":
+		return true
+	}
+	return false
+}
+
+type exprParser struct {
+	toks []exprToken
+	pos  int
+}
+
+func (p *exprParser) peekOp(ops ...string) (string, bool) {
+	if p.pos >= len(p.toks) || p.toks[p.pos].kind != "op" {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.toks[p.pos].text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) or() (*expr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.peekOp("||"); !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &expr{kind: exprOr, left: left, right: right}
+	}
+}
+
+func (p *exprParser) and() (*expr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.peekOp("&&"); !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &expr{kind: exprAnd, left: left, right: right}
+	}
+}
+
+func (p *exprParser) unary() (*expr, error) {
+	if _, ok := p.peekOp("!"); ok {
+		p.pos++
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &expr{kind: exprNot, left: operand}, nil
+	}
+	return p.compare()
+}
+
+func (p *exprParser) compare() (*expr, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.peekOp("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	p.pos++
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return &expr{kind: exprCompare, op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) operand() (*expr, error) {
+	if p.pos >= len(p.toks) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	t := p.toks[p.pos]
+	p.pos++
+	switch t.kind {
+	case "num", "str":
+		return &expr{kind: exprLiteral, value: t.val}, nil
+	case "ident":
+		switch t.text {
+		case "true":
+			return &expr{kind: exprLiteral, value: true}, nil
+		case "false":
+			return &expr{kind: exprLiteral, value: false}, nil
+		case "null":
+			return &expr{kind: exprLiteral, value: nil}, nil
+		}
+		return &expr{kind: exprPath, path: strings.Split(t.text, ".")}, nil
+	case "(":
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.toks) || p.toks[p.pos].kind != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return e, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", t.text)
+}
+
+// steps returns the step names referenced by the expression.
+func (e *expr) steps() []string {
+	if e == nil {
+		return nil
+	}
+	if e.kind == exprPath {
+		return []string{e.path[0]}
+	}
+	return append(e.left.steps(), e.right.steps()...)
+}
+
+// eval evaluates the expression as a condition.
+func (e *expr) eval(results WorkflowResult) (bool, error) {
+	v, err := e.evalValue(results)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition evaluated to %s, not a boolean", formatCompact(v))
+	}
+	return b, nil
+}
+
+func (e *expr) evalValue(results WorkflowResult) (any, error) {
+	switch e.kind {
+	case exprLiteral:
+		return e.value, nil
+	case exprPath:
+		return resolveResultPath(results, e.path), nil
+	case exprNot:
+		b, err := e.left.eval(results)
+		return !b, err
+	case exprAnd, exprOr:
+		l, err := e.left.eval(results)
+		if err != nil {
+			return nil, err
+		}
+		if l == (e.kind == exprOr) {
+			return l, nil
+		}
+		return e.right.eval(results)
+	case exprCompare:
+		l, err := e.left.evalValue(results)
+		if err != nil {
+			return nil, err
+		}
+		r, err := e.right.evalValue(results)
+		if err != nil {
+			return nil, err
+		}
+		return compareValues(e.op, l, r)
+	}
+	return nil, fmt.Errorf("invalid expression")
+}
+
+func resolveResultPath(results WorkflowResult, path []string) any {
+	r, ok := results.Results[path[0]]
+	if !ok || len(path) < 2 {
+		return nil
+	}
+	var cur any
+	switch path[1] {
+	case "exitCode":
+		cur = float64(r.ExitCode)
+	case "durationMs":
+		cur = float64(r.DurationMs)
+	case "error":
+		cur = r.Error
+	case "isError":
+		cur = r.IsError()
+	case "output":
+		cur = r.Output
+	default:
+		return nil
+	}
+	for _, seg := range path[2:] {
+		if jsonKind(cur) != kindObject {
+			return nil
+		}
+		cur = toObject(cur)[seg]
+	}
+	return cur
+}
+
+func compareValues(op string, l, r any) (bool, error) {
+	switch op {
+	case "==":
+		return valuesEqual(l, r), nil
+	case "!=":
+		return !valuesEqual(l, r), nil
+	}
+	if lf, ok := toFloat(l); ok {
+		if rf, ok := toFloat(r); ok {
+			return orderedCompare(op, lf, rf), nil
+		}
+	}
+	if ls, ok := l.(string); ok {
+		if rs, ok := r.(string); ok {
+			return orderedCompare(op, ls, rs), nil
+		}
+	}
+	return false, fmt.Errorf("cannot compare %s %s %s", formatCompact(l), op, formatCompact(r))
+}
+
+func orderedCompare[T float64 | string](op string, l, r T) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	}
+	return l >= r
+}
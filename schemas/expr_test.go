@@ -0,0 +1,57 @@
+package schemas
+
+import "testing"
+
+func TestExprEval(t *testing.T) {
+	var results WorkflowResult
+	results.Record("build", McpResult{Tool: "build", ExitCode: 0, Output: map[string]any{"artifact": "app.tar", "size": float64(42)}})
+	results.Record("test", McpResult{Tool: "test", ExitCode: 2, Error: "failed"})
+
+	tests := map[string]bool{
+		`build.exitCode == 0`:                                 true,
+		`test.exitCode != 0 && test.isError`:                  true,
+		`build.output.size > 40 and build.output.size <= 42`:  true,
+		`build.output.artifact == 'app.tar'`:                  true,
+		`build.output.missing == null`:                        true,
+		`!(build.exitCode == 0) || test.error == "failed"`:    true,
+		`not build.isError`:                                   true,
+		`build.exitCode == 1 || test.exitCode < 2`:            false,
+		`build.output.artifact < "b"`:                         true,
+		`unknown.exitCode == null`:                            true,
+		`(build.exitCode == 0 || test.exitCode == 0) && true`: true,
+	}
+	for src, want := range tests {
+		e, err := parseExpr(src)
+		if err != nil {
+			t.Errorf("parseExpr(%q): %v", src, err)
+			continue
+		}
+		got, err := e.eval(results)
+		if err != nil {
+			t.Errorf("eval(%q): %v", src, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("eval(%q) = %v, want %v", src, got, want)
+		}
+	}
+}
+
+func TestExprErrors(t *testing.T) {
+	for _, src := range []string{``, `a ==`, `(a == 1`, `a = 1`, `'open`, `a == 1 b`, `a & b`} {
+		if _, err := parseExpr(src); err == nil {
+			t.Errorf("parseExpr(%q) succeeded", src)
+		}
+	}
+	var results WorkflowResult
+	results.Record("a", McpResult{Output: "x"})
+	for _, src := range []string{`a.output`, `a.output < 1`} {
+		e, err := parseExpr(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := e.eval(results); err == nil {
+			t.Errorf("eval(%q) succeeded", src)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package schemas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxFormatStringLen is the length beyond which String truncates string values.
+const maxFormatStringLen = 40
+
+// String returns a compact single-line rendering of the request such as
+// search(limit=10, query="golang") with keys sorted, long strings truncated
+// and nested objects and arrays abbreviated to their size. Values under
+// DefaultSensitiveKeys are redacted, since the result ends up in error
+// messages and logs.
+func (m McpExecute) String() string {
+	m = m.Redacted(DefaultSensitiveKeys)
+	var b strings.Builder
+	b.WriteString(m.Tool)
+	b.WriteByte('(')
+	for i, k := range sortedKeys(m.Parameters) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(formatCompact(m.Parameters[k]))
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// Pretty returns an indented multi-line rendering of the request with keys
+// sorted and nested values shown in full, except that values under
+// DefaultSensitiveKeys are redacted as in String.
+func (m McpExecute) Pretty() string {
+	m = m.Redacted(DefaultSensitiveKeys)
+	var b strings.Builder
+	b.WriteString(m.Tool)
+	if len(m.Parameters) == 0 {
+		b.WriteString("()")
+		return b.String()
+	}
+	b.WriteString("(\n")
+	for _, k := range sortedKeys(m.Parameters) {
+		b.WriteString("  ")
+		b.WriteString(k)
+		b.WriteString(": ")
+		formatPretty(&b, m.Parameters[k], "  ")
+		b.WriteByte('\n')
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func formatCompact(v any) string {
+	switch kind := jsonKind(v); kind {
+	case kindString:
+		str, _ := stringValue(v)
+		return strconv.Quote(truncateString(str))
+	case kindObject:
+		return fmt.Sprintf("{…%d}", len(toObject(v)))
+	case kindArray:
+		return fmt.Sprintf("[…%d]", len(toArray(v)))
+	}
+	return formatScalar(v)
+}
+
+// truncateString shortens s to maxFormatStringLen runes, marking the cut with "…".
+func truncateString(s string) string {
+	if r := []rune(s); len(r) > maxFormatStringLen {
+		return string(r[:maxFormatStringLen]) + "…"
+	}
+	return s
+}
+
+func formatPretty(b *strings.Builder, v any, indent string) {
+	inner := indent + "  "
+	switch jsonKind(v) {
+	case kindString:
+		str, _ := stringValue(v)
+		b.WriteString(strconv.Quote(str))
+	case kindObject:
+		obj := toObject(v)
+		if len(obj) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for _, k := range sortedKeys(obj) {
+			b.WriteString(inner)
+			b.WriteString(k)
+			b.WriteString(": ")
+			formatPretty(b, obj[k], inner)
+			b.WriteByte('\n')
+		}
+		b.WriteString(indent)
+		b.WriteByte('}')
+	case kindArray:
+		arr := toArray(v)
+		if len(arr) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for _, item := range arr {
+			b.WriteString(inner)
+			formatPretty(b, item, inner)
+			b.WriteByte('\n')
+		}
+		b.WriteString(indent)
+		b.WriteByte(']')
+	default:
+		b.WriteString(formatScalar(v))
+	}
+}
+
+func formatScalar(v any) string {
+	v = deref(v)
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprint(v)
+}
+
+// String renders the wrapped request as McpExecute.String does, followed by
+// the envelope metadata that is set, such as
+// search(query="go") [correlationId="run-1"].
+func (e McpExecuteEnvelope) String() string {
+	var notes []string
+	if e.CorrelationID != "" {
+		notes = append(notes, "correlationId="+strconv.Quote(e.CorrelationID))
+	}
+	if e.Deadline != nil {
+		notes = append(notes, "deadline="+e.Deadline.Format(time.RFC3339Nano))
+	}
+	if e.IdempotencyKey != "" {
+		notes = append(notes, "idempotencyKey="+strconv.Quote(e.IdempotencyKey))
+	}
+	return annotate(e.McpExecute.String(), notes)
+}
+
+// String renders the wrapped request as McpExecute.String does, followed by
+// the approval requirement if there is one.
+func (g McpExecuteGuarded) String() string {
+	var notes []string
+	if g.ApprovalRequired {
+		notes = append(notes, "approvalRequired")
+	}
+	if g.ApprovalReason != "" {
+		notes = append(notes, "approvalReason="+strconv.Quote(g.ApprovalReason))
+	}
+	return annotate(g.McpExecute.String(), notes)
+}
+
+// String renders the wrapped request as McpExecute.String does, followed by
+// the policy values that are set.
+func (e McpExecuteWithPolicy) String() string {
+	var notes []string
+	if e.Policy.TimeoutMs != 0 {
+		notes = append(notes, fmt.Sprintf("timeoutMs=%d", e.Policy.TimeoutMs))
+	}
+	if e.Policy.MaxRetries != 0 {
+		notes = append(notes, fmt.Sprintf("maxRetries=%d", e.Policy.MaxRetries))
+	}
+	if e.Policy.RetryBackoffMs != 0 {
+		notes = append(notes, fmt.Sprintf("retryBackoffMs=%d", e.Policy.RetryBackoffMs))
+	}
+	return annotate(e.McpExecute.String(), notes)
+}
+
+// annotate appends the bracketed, space-separated notes to s.
+func annotate(s string, notes []string) string {
+	if len(notes) == 0 {
+		return s
+	}
+	return s + " [" + strings.Join(notes, " ") + "]"
+}
@@ -0,0 +1,115 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMcpExecuteString(t *testing.T) {
+	m := McpExecute{Tool: "search", Parameters: map[string]any{
+		"query":  "golang",
+		"limit":  float64(10),
+		"opts":   map[string]any{"a": 1, "b": 2},
+		"tags":   []any{"x"},
+		"none":   nil,
+		"prompt": strings.Repeat("y", 50),
+	}}
+	want := `search(limit=10, none=null, opts={…2}, prompt="` + strings.Repeat("y", 40) + `…", query="golang", tags=[…1])`
+	if got := m.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+	if got := (McpExecute{Tool: "ping"}).String(); got != "ping()" {
+		t.Errorf("String() = %s", got)
+	}
+}
+
+func TestMcpExecutePretty(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service": "api",
+		"config":  map[string]any{"regions": []any{"eu", "us"}, "replicas": 2},
+	}}
+	want := `deploy(
+  config: {
+    regions: [
+      "eu"
+      "us"
+    ]
+    replicas: 2
+  }
+  service: "api"
+)`
+	if got := m.Pretty(); got != want {
+		t.Errorf("Pretty() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatPointerParams(t *testing.T) {
+	name, on, n := "api", true, 3
+	var missing *string
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service": &name, "dryRun": &on, "replicas": &n, "parent": missing,
+	}}
+	if got, want := m.String(), `deploy(dryRun=true, parent=null, replicas=3, service="api")`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	if got := m.Pretty(); !strings.Contains(got, `service: "api"`) || !strings.Contains(got, "parent: null") {
+		t.Errorf("Pretty() = %s", got)
+	}
+}
+
+func TestWrapperString(t *testing.T) {
+	deadline := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := McpExecute{Tool: "t", Parameters: map[string]any{"a": 1}}
+	tests := []struct {
+		v    fmt.Stringer
+		want string
+	}{
+		{McpExecuteEnvelope{McpExecute: base, CorrelationID: "run-1", Deadline: &deadline, IdempotencyKey: "k"},
+			`t(a=1) [correlationId="run-1" deadline=2026-01-01T00:00:00Z idempotencyKey="k"]`},
+		{McpExecuteEnvelope{McpExecute: base}, `t(a=1)`},
+		{McpExecuteGuarded{McpExecute: base, ApprovalRequired: true, ApprovalReason: "drops data"},
+			`t(a=1) [approvalRequired approvalReason="drops data"]`},
+		{McpExecuteWithPolicy{McpExecute: base, Policy: McpExecutePolicy{TimeoutMs: 500, MaxRetries: 2}},
+			`t(a=1) [timeoutMs=500 maxRetries=2]`},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(tt.v); got != tt.want {
+			t.Errorf("Sprint = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestFormatRedactsSensitiveKeys(t *testing.T) {
+	m := McpExecute{Tool: "login", Parameters: map[string]any{
+		"user":     "jules",
+		"password": "hunter2",
+		"headers":  map[string]string{"Authorization": "Bearer abc"},
+	}}
+	env := McpExecuteEnvelope{McpExecute: m, CorrelationID: "run-1"}
+	for name, s := range map[string]string{"String": m.String(), "Pretty": m.Pretty(), "envelope": env.String()} {
+		if strings.Contains(s, "hunter2") || strings.Contains(s, "Bearer") {
+			t.Errorf("%s leaks a secret: %s", name, s)
+		}
+	}
+	if got := m.String(); !strings.Contains(got, `password="***"`) || !strings.Contains(got, `user="jules"`) {
+		t.Errorf("String = %s", got)
+	}
+	if m.Parameters["password"] != "hunter2" {
+		t.Error("String modified the request")
+	}
+
+	replay, err := NewReplayExecutor(strings.NewReader(""), ReplayOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = replay.Execute(context.Background(), m)
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("unexpected call error = %v", err)
+	}
+	if err := replay.Verify(); err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("Verify = %v", err)
+	}
+}
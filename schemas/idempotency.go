@@ -0,0 +1,167 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is reused for
+// a different execution request.
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused for a different request")
+
+type idempotencyKeyContext struct{}
+
+// WithIdempotencyKey returns a context carrying the idempotency key used by
+// IdempotentExecutor.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContext{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by ctx, or "".
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContext{}).(string)
+	return key
+}
+
+// IdempotencyOptions configures an IdempotentExecutor.
+type IdempotencyOptions struct {
+	// TTL is how long a completed key is remembered. Zero means forever, so
+	// the store grows with every distinct key until Forget is called;
+	// long-running callers should set a TTL.
+	TTL time.Duration
+	// Now returns the current time; it defaults to time.Now.
+	Now func() time.Time
+}
+
+type idempotentCall struct {
+	fingerprint string
+	done        chan struct{}
+	result      McpResult
+	err         error
+	expires     time.Time
+}
+
+// IdempotentExecutor is an Executor that runs each idempotency key at most
+// once. A repeated key returns the stored result, including tool-level
+// failures, and a duplicate arriving while the first call is still in
+// flight waits for it. Calls that fail with a Go error are not remembered,
+// so they can be retried. Requests without a key are passed through. It is
+// safe for concurrent use.
+type IdempotentExecutor struct {
+	next Executor
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu        sync.Mutex
+	calls     map[string]*idempotentCall
+	nextSweep time.Time
+}
+
+// NewIdempotentExecutor returns an IdempotentExecutor wrapping next.
+func NewIdempotentExecutor(next Executor, opts IdempotencyOptions) *IdempotentExecutor {
+	e := &IdempotentExecutor{
+		next:  next,
+		ttl:   opts.TTL,
+		now:   opts.Now,
+		calls: make(map[string]*idempotentCall),
+	}
+	if e.now == nil {
+		e.now = time.Now
+	}
+	return e
+}
+
+// ExecuteEnvelope executes the wrapped request using the envelope's
+// idempotency key.
+func (e *IdempotentExecutor) ExecuteEnvelope(ctx context.Context, env McpExecuteEnvelope) (McpResult, error) {
+	if env.IdempotencyKey != "" {
+		ctx = WithIdempotencyKey(ctx, env.IdempotencyKey)
+	}
+	return e.Execute(ctx, env.McpExecute)
+}
+
+// Execute runs m under the idempotency key carried by ctx. Reusing a key for
+// a request with a different fingerprint returns ErrIdempotencyKeyReused.
+func (e *IdempotentExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	key := IdempotencyKeyFromContext(ctx)
+	if key == "" {
+		return e.next.Execute(ctx, m)
+	}
+	fp := m.Fingerprint()
+	for {
+		e.mu.Lock()
+		now := e.now()
+		e.sweep(now)
+		call, ok := e.calls[key]
+		if ok && call.expired(now) {
+			delete(e.calls, key)
+			ok = false
+		}
+		if !ok {
+			call = &idempotentCall{fingerprint: fp, done: make(chan struct{})}
+			e.calls[key] = call
+			e.mu.Unlock()
+			return e.run(ctx, key, call, m)
+		}
+		e.mu.Unlock()
+		if call.fingerprint != fp {
+			return McpResult{}, fmt.Errorf("%w: %q", ErrIdempotencyKeyReused, key)
+		}
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return McpResult{}, ctx.Err()
+		}
+		if call.err == nil {
+			return call.result, nil
+		}
+		// The first call failed and was forgotten; try to run it ourselves.
+	}
+}
+
+func (c *idempotentCall) expired(now time.Time) bool {
+	return !c.expires.IsZero() && !now.Before(c.expires)
+}
+
+// sweep drops expired calls. It scans the store at most once per TTL, so
+// the cost is amortized over the calls in between. e.mu must be held.
+func (e *IdempotentExecutor) sweep(now time.Time) {
+	if e.ttl <= 0 || now.Before(e.nextSweep) {
+		return
+	}
+	for key, call := range e.calls {
+		if call.expired(now) {
+			delete(e.calls, key)
+		}
+	}
+	e.nextSweep = now.Add(e.ttl)
+}
+
+// Forget drops the stored result for key so the next call runs again.
+func (e *IdempotentExecutor) Forget(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if call, ok := e.calls[key]; ok {
+		select {
+		case <-call.done:
+			delete(e.calls, key)
+		default:
+		}
+	}
+}
+
+func (e *IdempotentExecutor) run(ctx context.Context, key string, call *idempotentCall, m McpExecute) (McpResult, error) {
+	call.result, call.err = e.next.Execute(ctx, m)
+	e.mu.Lock()
+	if call.err != nil {
+		delete(e.calls, key)
+	} else if e.ttl > 0 {
+		call.expires = e.now().Add(e.ttl)
+	}
+	e.mu.Unlock()
+	close(call.done)
+	return call.result, call.err
+}
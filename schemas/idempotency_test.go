@@ -0,0 +1,108 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIdempotentExecutorConcurrentDuplicates(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	next := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		calls.Add(1)
+		<-release
+		return McpResult{Tool: m.Tool, Output: "charged"}, nil
+	})
+	e := NewIdempotentExecutor(next, IdempotencyOptions{})
+	env := McpExecuteEnvelope{
+		McpExecute:     McpExecute{Tool: "charge", Parameters: map[string]any{"amount": 5.0}},
+		IdempotencyKey: "order-1",
+	}
+
+	var wg sync.WaitGroup
+	results := make([]McpResult, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := e.ExecuteEnvelope(context.Background(), env)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = r
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("wrapped executor called %d times, want 1", n)
+	}
+	for i, r := range results {
+		if r.Output != "charged" {
+			t.Errorf("results[%d] = %+v", i, r)
+		}
+	}
+	if _, err := e.ExecuteEnvelope(context.Background(), env); err != nil || calls.Load() != 1 {
+		t.Errorf("completed key re-executed (calls=%d, err=%v)", calls.Load(), err)
+	}
+
+	env.Parameters = map[string]any{"amount": 6.0}
+	if _, err := e.ExecuteEnvelope(context.Background(), env); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Errorf("expected ErrIdempotencyKeyReused, got %v", err)
+	}
+}
+
+func TestIdempotentExecutorRetriesAndExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var calls int
+	fail := true
+	next := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		calls++
+		if fail {
+			return McpResult{}, errors.New("connection reset")
+		}
+		return McpResult{Tool: m.Tool}, nil
+	})
+	e := NewIdempotentExecutor(next, IdempotencyOptions{TTL: time.Minute, Now: func() time.Time { return now }})
+	ctx := WithIdempotencyKey(context.Background(), "k")
+	m := McpExecute{Tool: "t"}
+
+	if _, err := e.Execute(ctx, m); err == nil {
+		t.Fatal("expected transport error")
+	}
+	fail = false
+	for i := 0; i < 2; i++ {
+		if _, err := e.Execute(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2 (failed call retried, success remembered)", calls)
+	}
+	now = now.Add(time.Minute)
+	e.Execute(ctx, m)
+	e.Forget("k")
+	e.Execute(ctx, m)
+	e.Execute(context.Background(), m)
+	if calls != 5 {
+		t.Errorf("calls = %d, want 5 after expiry, Forget and an unkeyed call", calls)
+	}
+
+	for _, key := range []string{"a", "b", "c"} {
+		e.Execute(WithIdempotencyKey(context.Background(), key), m)
+	}
+	now = now.Add(2 * time.Minute)
+	e.Execute(WithIdempotencyKey(context.Background(), "d"), m)
+	e.mu.Lock()
+	stored := len(e.calls)
+	e.mu.Unlock()
+	if stored != 1 {
+		t.Errorf("stored %d keys after expiry, want 1", stored)
+	}
+}
@@ -0,0 +1,117 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// InferSchema derives a JSON Schema for the parameters of a tool from
+// example calls. Keys are unioned across examples and only those present in
+// every example are marked required. A key observed with several types
+// gets a list of types, with "integer" folded into "number" when both
+// occur. Nested objects and array elements are inferred recursively.
+func InferSchema(examples []McpExecute) (json.RawMessage, error) {
+	if len(examples) == 0 {
+		return nil, &ValidationError{Field: "examples", Message: "must not be empty"}
+	}
+	root := newShapeNode()
+	tool := examples[0].Tool
+	for i, m := range examples {
+		if m.Tool != tool {
+			return nil, &ValidationError{Field: fmt.Sprintf("examples[%d].tool", i), Message: fmt.Sprintf("expected %q, got %q", tool, m.Tool)}
+		}
+		params := m.Parameters
+		if params == nil {
+			params = map[string]any{}
+		}
+		if err := root.observe("", params); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(root.schema())
+}
+
+// shapeNode accumulates the values observed at one position in the parameters.
+type shapeNode struct {
+	kinds   map[string]struct{}
+	objects int
+	props   map[string]*shapeNode
+	present map[string]int
+	items   *shapeNode
+}
+
+func newShapeNode() *shapeNode {
+	return &shapeNode{kinds: make(map[string]struct{})}
+}
+
+func (n *shapeNode) observe(path string, v any) error {
+	kind := jsonKind(v)
+	if kind == "" {
+		return fmt.Errorf("parameters.%s: unsupported value of type %T", path, v)
+	}
+	n.kinds[kind] = struct{}{}
+	switch kind {
+	case kindObject:
+		if n.props == nil {
+			n.props = make(map[string]*shapeNode)
+			n.present = make(map[string]int)
+		}
+		n.objects++
+		for k, item := range toObject(v) {
+			child, ok := n.props[k]
+			if !ok {
+				child = newShapeNode()
+				n.props[k] = child
+			}
+			n.present[k]++
+			if err := child.observe(joinPath(path, k), item); err != nil {
+				return err
+			}
+		}
+	case kindArray:
+		if n.items == nil {
+			n.items = newShapeNode()
+		}
+		for i, item := range toArray(v) {
+			if err := n.items.observe(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n *shapeNode) schema() *jsonSchema {
+	s := &jsonSchema{}
+	kinds := make([]string, 0, len(n.kinds))
+	_, hasNumber := n.kinds[kindNumber]
+	for k := range n.kinds {
+		if k == kindInteger && hasNumber {
+			continue
+		}
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	switch len(kinds) {
+	case 0:
+	case 1:
+		s.Type, _ = json.Marshal(kinds[0])
+	default:
+		s.Type, _ = json.Marshal(kinds)
+	}
+	if n.props != nil {
+		s.Properties = make(map[string]*jsonSchema, len(n.props))
+		for k, child := range n.props {
+			s.Properties[k] = child.schema()
+			if n.present[k] == n.objects {
+				s.Required = append(s.Required, k)
+			}
+		}
+		sort.Strings(s.Required)
+	}
+	if n.items != nil && len(n.items.kinds) > 0 {
+		s.Items = n.items.schema()
+	}
+	return s
+}
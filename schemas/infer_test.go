@@ -0,0 +1,45 @@
+package schemas
+
+import "testing"
+
+func TestInferSchema(t *testing.T) {
+	examples := []McpExecute{
+		{Tool: "search", Parameters: map[string]any{
+			"query": "go",
+			"limit": float64(10),
+			"opts":  map[string]any{"deep": true},
+			"tags":  []any{"a", "b"},
+		}},
+		{Tool: "search", Parameters: map[string]any{
+			"query": "rust",
+			"limit": 2.5,
+			"opts":  map[string]any{"deep": false, "lang": "en"},
+			"owner": nil,
+		}},
+	}
+	got, err := InferSchema(examples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"object","properties":{` +
+		`"limit":{"type":"number"},` +
+		`"opts":{"type":"object","properties":{"deep":{"type":"boolean"},"lang":{"type":"string"}},"required":["deep"]},` +
+		`"owner":{"type":"null"},` +
+		`"query":{"type":"string"},` +
+		`"tags":{"type":"array","items":{"type":"string"}}},` +
+		`"required":["limit","opts","query"]}`
+	if string(got) != want {
+		t.Errorf("InferSchema =\n%s\nwant\n%s", got, want)
+	}
+
+	def := ToolDefinition{Name: "search", InputSchema: got}
+	for _, m := range examples {
+		if err := m.ValidateAgainst(def); err != nil {
+			t.Errorf("example does not validate against inferred schema: %v", err)
+		}
+	}
+
+	if _, err := InferSchema([]McpExecute{{Tool: "a"}, {Tool: "b"}}); err == nil {
+		t.Error("examples for different tools accepted")
+	}
+}
@@ -0,0 +1,78 @@
+package schemas
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// LimitOptions bounds the size and shape of an execution request. A zero
+// field disables the corresponding limit.
+type LimitOptions struct {
+	MaxTotalBytes int `json:"maxTotalBytes,omitempty" doc:"The maximum size of the JSON-encoded request in bytes."`
+	MaxDepth      int `json:"maxDepth,omitempty" doc:"The maximum nesting depth of parameters; top-level parameters have depth 1."`
+	MaxStringLen  int `json:"maxStringLen,omitempty" doc:"The maximum length in bytes of any string parameter."`
+}
+
+// LimitError reports that a request exceeds one of its LimitOptions.
+type LimitError struct {
+	Limit  string
+	Max    int
+	Actual int
+	Path   string
+}
+
+func (e *LimitError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s exceeded: %d > %d", e.Limit, e.Actual, e.Max)
+	}
+	return fmt.Sprintf("%s exceeded at parameters.%s: %d > %d", e.Limit, e.Path, e.Actual, e.Max)
+}
+
+// EnforceLimits returns a *LimitError naming the first limit the request
+// breaches. Depth and string length are checked before the size of the
+// canonical JSON encoding.
+func (m McpExecute) EnforceLimits(opts LimitOptions) error {
+	if opts.MaxDepth > 0 || opts.MaxStringLen > 0 {
+		for _, k := range sortedKeys(m.Parameters) {
+			if err := checkShape(opts, k, m.Parameters[k], 1); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.MaxTotalBytes > 0 {
+		data, err := m.MarshalCanonical()
+		if err != nil {
+			return err
+		}
+		if len(data) > opts.MaxTotalBytes {
+			return &LimitError{Limit: "maxTotalBytes", Max: opts.MaxTotalBytes, Actual: len(data)}
+		}
+	}
+	return nil
+}
+
+func checkShape(opts LimitOptions, path string, v any, depth int) error {
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return &LimitError{Limit: "maxDepth", Max: opts.MaxDepth, Actual: depth, Path: path}
+	}
+	switch jsonKind(v) {
+	case kindString:
+		if s, _ := stringValue(v); opts.MaxStringLen > 0 && len(s) > opts.MaxStringLen {
+			return &LimitError{Limit: "maxStringLen", Max: opts.MaxStringLen, Actual: len(s), Path: path}
+		}
+	case kindObject:
+		obj := toObject(v)
+		for _, k := range sortedKeys(obj) {
+			if err := checkShape(opts, joinPath(path, k), obj[k], depth+1); err != nil {
+				return err
+			}
+		}
+	case kindArray:
+		for i, item := range toArray(v) {
+			if err := checkShape(opts, path+"["+strconv.Itoa(i)+"]", item, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
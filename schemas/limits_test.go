@@ -0,0 +1,69 @@
+package schemas
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnforceLimits(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"name":   "short",
+		"nested": map[string]any{"list": []any{map[string]any{"deep": "x"}}},
+	}}
+	if err := m.EnforceLimits(LimitOptions{MaxTotalBytes: 1024, MaxDepth: 4, MaxStringLen: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		opts  LimitOptions
+		limit string
+		path  string
+	}{
+		{LimitOptions{MaxDepth: 3}, "maxDepth", "nested.list[0].deep"},
+		{LimitOptions{MaxStringLen: 4}, "maxStringLen", "name"},
+		{LimitOptions{MaxTotalBytes: 20}, "maxTotalBytes", ""},
+	}
+	for _, tt := range tests {
+		err := m.EnforceLimits(tt.opts)
+		var lerr *LimitError
+		if !errors.As(err, &lerr) {
+			t.Fatalf("%+v: expected *LimitError, got %v", tt.opts, err)
+		}
+		if lerr.Limit != tt.limit || lerr.Path != tt.path {
+			t.Errorf("%+v: got limit %q path %q", tt.opts, lerr.Limit, lerr.Path)
+		}
+	}
+}
+
+func TestEnforceLimitsExactSize(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{"blob": strings.Repeat("a", 100)}}
+	data, err := m.MarshalCanonical()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.EnforceLimits(LimitOptions{MaxTotalBytes: len(data)}); err != nil {
+		t.Errorf("request of exactly the limit rejected: %v", err)
+	}
+	if err := m.EnforceLimits(LimitOptions{MaxTotalBytes: len(data) - 1}); err == nil {
+		t.Error("expected request one byte over the limit to be rejected")
+	}
+}
+
+func TestEnforceLimitsPointerStrings(t *testing.T) {
+	long, short := strings.Repeat("x", 20), "ok"
+	var missing *string
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"short":  &short,
+		"unset":  missing,
+		"nested": map[string]*string{"long": &long},
+	}}
+	err := m.EnforceLimits(LimitOptions{MaxStringLen: 10})
+	var lerr *LimitError
+	if !errors.As(err, &lerr) || lerr.Path != "nested.long" || lerr.Actual != 20 {
+		t.Errorf("expected maxStringLen error at nested.long, got %v", err)
+	}
+	if err := m.EnforceLimits(LimitOptions{MaxStringLen: 20}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// AnthropicToolUse defines an Anthropic Messages API tool_use content block.
+type AnthropicToolUse struct {
+	Type  string          `json:"type" doc:"The block type, always \"tool_use\"."`
+	ID    string          `json:"id" doc:"The identifier of the tool call."`
+	Name  string          `json:"name" doc:"The name of the tool to call."`
+	Input json.RawMessage `json:"input" doc:"The tool arguments as a JSON object."`
+}
+
+// FromOpenAIToolCall converts an OpenAI function tool call to an execution
+// request. argsJSON may be the arguments object itself or, as OpenAI
+// returns it, a JSON string containing the object. Numbers are decoded as
+// json.Number so that they round-trip exactly.
+func FromOpenAIToolCall(name string, argsJSON json.RawMessage) (McpExecute, error) {
+	args := bytes.TrimSpace(argsJSON)
+	if len(args) > 0 && args[0] == '"' {
+		var s string
+		if err := json.Unmarshal(args, &s); err != nil {
+			return McpExecute{}, fmt.Errorf("tool %q: invalid arguments: %w", name, err)
+		}
+		args = bytes.TrimSpace([]byte(s))
+	}
+	return toolCall(name, args)
+}
+
+// ToOpenAIToolCall returns the function name and arguments object for an
+// OpenAI tool call. Unlike FromOpenAIToolCall, the arguments are not
+// wrapped in a JSON string; callers that build the wire message can quote
+// them as required.
+func (m McpExecute) ToOpenAIToolCall() (name string, argsJSON json.RawMessage, err error) {
+	args, err := m.arguments()
+	if err != nil {
+		return "", nil, err
+	}
+	return m.Tool, args, nil
+}
+
+// FromAnthropicToolUse converts an Anthropic tool_use block to an execution request.
+func FromAnthropicToolUse(block AnthropicToolUse) (McpExecute, error) {
+	if block.Type != "" && block.Type != "tool_use" {
+		return McpExecute{}, &ValidationError{Field: "type", Message: fmt.Sprintf("expected \"tool_use\", got %q", block.Type)}
+	}
+	return toolCall(block.Name, bytes.TrimSpace(block.Input))
+}
+
+// ToAnthropicToolUse returns the request as an Anthropic tool_use block with the given id.
+func (m McpExecute) ToAnthropicToolUse(id string) (AnthropicToolUse, error) {
+	input, err := m.arguments()
+	if err != nil {
+		return AnthropicToolUse{}, err
+	}
+	return AnthropicToolUse{Type: "tool_use", ID: id, Name: m.Tool, Input: input}, nil
+}
+
+// toolCall builds a validated request from a tool name and an arguments
+// object. Empty arguments produce nil parameters.
+func toolCall(name string, args []byte) (McpExecute, error) {
+	m := McpExecute{Tool: name}
+	if len(args) > 0 && string(args) != "null" {
+		dec := json.NewDecoder(bytes.NewReader(args))
+		dec.UseNumber()
+		if err := dec.Decode(&m.Parameters); err != nil {
+			return McpExecute{}, fmt.Errorf("tool %q: arguments must be a JSON object: %w", name, err)
+		}
+		if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+			return McpExecute{}, fmt.Errorf("tool %q: unexpected data after arguments", name)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		return McpExecute{}, err
+	}
+	return m, nil
+}
+
+// arguments encodes the parameters as a JSON object, using {} when empty.
+func (m McpExecute) arguments() (json.RawMessage, error) {
+	if len(m.Parameters) == 0 {
+		return json.RawMessage("{}"), nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(m.Parameters); err != nil {
+		return nil, fmt.Errorf("tool %q: %w", m.Tool, err)
+	}
+	return json.RawMessage(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
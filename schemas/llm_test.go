@@ -0,0 +1,66 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenAIToolCallRoundTrip(t *testing.T) {
+	for _, args := range []string{
+		`{"query":"go","limit":9007199254740993,"ratio":0.5}`,
+		`"{\"query\":\"go\",\"limit\":9007199254740993,\"ratio\":0.5}"`,
+	} {
+		m, err := FromOpenAIToolCall("search", json.RawMessage(args))
+		if err != nil {
+			t.Fatalf("FromOpenAIToolCall(%s): %v", args, err)
+		}
+		if n, err := m.GetInt("limit"); err != nil || n != 9007199254740993 {
+			t.Errorf("limit = %d, %v; want exact integer", n, err)
+		}
+		name, out, err := m.ToOpenAIToolCall()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "search" || string(out) != `{"limit":9007199254740993,"query":"go","ratio":0.5}` {
+			t.Errorf("ToOpenAIToolCall = %s, %s", name, out)
+		}
+	}
+
+	if _, err := FromOpenAIToolCall("search", json.RawMessage(`[1]`)); err == nil {
+		t.Error("non-object arguments accepted")
+	}
+	for _, args := range []string{`{"a":1} ]`, `{"a":1} {"b":2}`, `{"a":1} x`} {
+		if _, err := FromOpenAIToolCall("search", json.RawMessage(args)); err == nil {
+			t.Errorf("trailing data accepted: %s", args)
+		}
+	}
+	if _, err := FromOpenAIToolCall("search", json.RawMessage("{\"a\":1}\n")); err != nil {
+		t.Errorf("trailing whitespace rejected: %v", err)
+	}
+	m, err := FromOpenAIToolCall("ping", nil)
+	if err != nil || m.Parameters != nil {
+		t.Errorf("empty arguments = %+v, %v", m, err)
+	}
+}
+
+func TestAnthropicToolUseRoundTrip(t *testing.T) {
+	block := AnthropicToolUse{Type: "tool_use", ID: "toolu_1", Name: "read_file", Input: json.RawMessage(`{"path":"a.go"}`)}
+	m, err := FromAnthropicToolUse(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Tool != "read_file" || m.Parameters["path"] != "a.go" {
+		t.Errorf("FromAnthropicToolUse = %+v", m)
+	}
+	back, err := m.ToAnthropicToolUse("toolu_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Type != "tool_use" || back.ID != "toolu_1" || string(back.Input) != `{"path":"a.go"}` {
+		t.Errorf("ToAnthropicToolUse = %+v", back)
+	}
+
+	if _, err := FromAnthropicToolUse(AnthropicToolUse{Type: "text", Name: "x"}); err == nil {
+		t.Error("non tool_use block accepted")
+	}
+}
@@ -2,6 +2,32 @@ package schemas
 
 // McpExecute defines the structure for executing an MCP tool.
 type McpExecute struct {
-	Tool       string         `json:"tool" doc:"The name of the tool to execute."`
-	Parameters map[string]any `json:"parameters,omitempty" doc:"Arbitrary parameters for the tool."`
+	Tool          string         `json:"tool" yaml:"tool" doc:"The name of the tool to execute."`
+	Parameters    map[string]any `json:"parameters,omitempty" yaml:"parameters,omitempty" doc:"Arbitrary parameters for the tool."`
+	SchemaVersion int            `json:"schemaVersion,omitempty" yaml:"schemaVersion,omitempty" doc:"The payload schema version; absent means the current version."`
+}
+
+// Version returns the payload schema version, defaulting to
+// CurrentSchemaVersion when SchemaVersion is unset.
+func (m McpExecute) Version() int {
+	if m.SchemaVersion == 0 {
+		return CurrentSchemaVersion
+	}
+	return m.SchemaVersion
+}
+
+// McpResult defines the structure of the output returned by an MCP tool.
+type McpResult struct {
+	Tool        string    `json:"tool" doc:"The name of the tool that was executed."`
+	Output      any       `json:"output,omitempty" doc:"Arbitrary output returned by the tool."`
+	Error       string    `json:"error,omitempty" doc:"The error message, if the execution failed."`
+	ErrorDetail *McpError `json:"errorDetail,omitempty" doc:"The structured error, if the execution failed."`
+	DurationMs  int64     `json:"durationMs" doc:"The execution time in milliseconds."`
+	ExitCode    int       `json:"exitCode" doc:"The exit code reported by the tool."`
+}
+
+// IsError reports whether the result carries an error message or a
+// structured error.
+func (r McpResult) IsError() bool {
+	return r.Error != "" || r.ErrorDetail != nil
 }
@@ -0,0 +1,6 @@
+// Package mcppb contains the protobuf representation of the MCP schemas for
+// transport over gRPC. Conversions to and from the schemas types live in the
+// schemas package.
+package mcppb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative mcp.proto
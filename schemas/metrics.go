@@ -0,0 +1,57 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MetricsSink receives measurements from an InstrumentedExecutor. Backends
+// such as Prometheus typically record ObserveDuration in a per-tool
+// histogram, whose count doubles as the call count, and IncError in a
+// counter labelled by tool and code.
+type MetricsSink interface {
+	ObserveDuration(tool string, d time.Duration)
+	IncError(tool string, code McpErrorCode)
+}
+
+// InstrumentedExecutor is an Executor that reports the latency and failures
+// of every call to a MetricsSink.
+type InstrumentedExecutor struct {
+	next Executor
+	sink MetricsSink
+	now  func() time.Time
+}
+
+// NewInstrumentedExecutor returns an InstrumentedExecutor wrapping next.
+func NewInstrumentedExecutor(next Executor, sink MetricsSink) *InstrumentedExecutor {
+	return &InstrumentedExecutor{next: next, sink: sink, now: time.Now}
+}
+
+// Execute delegates to the wrapped Executor, observing the call duration
+// and counting an error if the call fails or returns a failed result.
+func (e *InstrumentedExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	start := e.now()
+	r, err := e.next.Execute(ctx, m)
+	e.sink.ObserveDuration(m.Tool, e.now().Sub(start))
+	if err != nil {
+		e.sink.IncError(m.Tool, errorCode(err))
+	} else if serr, failed := r.StructuredError(); failed {
+		e.sink.IncError(m.Tool, serr.Code)
+	}
+	return r, err
+}
+
+// errorCode classifies a Go error returned by an Executor.
+func errorCode(err error) McpErrorCode {
+	var merr *McpError
+	switch {
+	case errors.As(err, &merr):
+		return merr.Code
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	case errors.Is(err, context.Canceled):
+		return Cancelled
+	}
+	return Unknown
+}
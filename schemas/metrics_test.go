@@ -0,0 +1,64 @@
+package schemas
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memorySink struct {
+	mu        sync.Mutex
+	durations map[string][]time.Duration
+	errors    map[string]map[McpErrorCode]int
+}
+
+func (s *memorySink) ObserveDuration(tool string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.durations == nil {
+		s.durations = make(map[string][]time.Duration)
+	}
+	s.durations[tool] = append(s.durations[tool], d)
+}
+
+func (s *memorySink) IncError(tool string, code McpErrorCode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.errors == nil {
+		s.errors = make(map[string]map[McpErrorCode]int)
+	}
+	if s.errors[tool] == nil {
+		s.errors[tool] = make(map[McpErrorCode]int)
+	}
+	s.errors[tool][code]++
+}
+
+func TestInstrumentedExecutor(t *testing.T) {
+	next := ExecutorFunc(func(ctx context.Context, m McpExecute) (McpResult, error) {
+		switch m.Tool {
+		case "fail":
+			return ErrorResult(m.Tool, &McpError{Code: InvalidParams, Message: "bad"}), nil
+		case "timeout":
+			return McpResult{}, context.DeadlineExceeded
+		}
+		return McpResult{Tool: m.Tool}, nil
+	})
+	sink := &memorySink{}
+	e := NewInstrumentedExecutor(next, sink)
+	for _, tool := range []string{"ok", "ok", "fail", "timeout"} {
+		e.Execute(context.Background(), McpExecute{Tool: tool})
+	}
+
+	if n := len(sink.durations["ok"]); n != 2 {
+		t.Errorf("ok observations = %d, want 2", n)
+	}
+	want := map[string]map[McpErrorCode]int{
+		"fail":    {InvalidParams: 1},
+		"timeout": {Timeout: 1},
+	}
+	if !reflect.DeepEqual(sink.errors, want) {
+		t.Errorf("errors = %v, want %v", sink.errors, want)
+	}
+}
@@ -0,0 +1,113 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// CurrentSchemaVersion is the McpExecute payload version produced by this
+// package.
+const CurrentSchemaVersion = 1
+
+// maxMigrationSteps bounds Migrate so a misbehaving chain cannot loop forever.
+const maxMigrationSteps = 64
+
+// Migration upgrades a raw payload of the version it is registered for. It
+// returns the payload with its schemaVersion field set to the new version;
+// a migration registered for CurrentSchemaVersion may leave it unchanged.
+type Migration func(raw json.RawMessage) (json.RawMessage, error)
+
+var migrations = struct {
+	sync.RWMutex
+	byVersion map[int]Migration
+}{byVersion: map[int]Migration{
+	1: func(raw json.RawMessage) (json.RawMessage, error) { return raw, nil },
+}}
+
+// RegisterMigration registers fn as the migration for payloads at version
+// from, replacing any existing one.
+func RegisterMigration(from int, fn Migration) {
+	migrations.Lock()
+	defer migrations.Unlock()
+	migrations.byVersion[from] = fn
+}
+
+func migrationFor(version int) Migration {
+	migrations.RLock()
+	defer migrations.RUnlock()
+	return migrations.byVersion[version]
+}
+
+// Migrate decodes a payload of any supported version, applying registered
+// migrations in turn until it reaches CurrentSchemaVersion. A payload
+// without a schemaVersion field is treated as the current version. The
+// migrated payload is decoded with DecodeStrict, and the returned request
+// has SchemaVersion set to CurrentSchemaVersion.
+func Migrate(raw json.RawMessage) (McpExecute, error) {
+	raw, err := migrateRaw(raw, CurrentSchemaVersion, migrationFor)
+	if err != nil {
+		return McpExecute{}, err
+	}
+	m, err := DecodeStrict(raw)
+	if err != nil {
+		return McpExecute{}, err
+	}
+	m.SchemaVersion = CurrentSchemaVersion
+	return m, nil
+}
+
+// migrateRaw upgrades raw to version target using the migrations returned
+// by lookup.
+func migrateRaw(raw json.RawMessage, target int, lookup func(version int) Migration) (json.RawMessage, error) {
+	version, err := payloadVersion(raw, target)
+	if err != nil {
+		return nil, err
+	}
+	for step := 0; ; step++ {
+		if version > target {
+			return nil, &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("unsupported version %d", version)}
+		}
+		if step == maxMigrationSteps {
+			return nil, fmt.Errorf("migrate from version %d: too many steps", version)
+		}
+		fn := lookup(version)
+		if fn == nil {
+			if version == target {
+				return raw, nil
+			}
+			return nil, fmt.Errorf("no migration registered for version %d", version)
+		}
+		if raw, err = fn(raw); err != nil {
+			return nil, fmt.Errorf("migrate from version %d: %w", version, err)
+		}
+		next, err := payloadVersion(raw, target)
+		if err != nil {
+			return nil, fmt.Errorf("migrate from version %d: %w", version, err)
+		}
+		if next == version && version == target {
+			return raw, nil
+		}
+		if next <= version {
+			return nil, fmt.Errorf("migration from version %d produced version %d", version, next)
+		}
+		version = next
+	}
+}
+
+// payloadVersion reads the schemaVersion field of raw, defaulting to current.
+func payloadVersion(raw json.RawMessage, current int) (int, error) {
+	var header struct {
+		SchemaVersion *int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return 0, fmt.Errorf("decode McpExecute: %w", err)
+	}
+	if header.SchemaVersion == nil || *header.SchemaVersion == 0 {
+		return current, nil
+	}
+	if *header.SchemaVersion < 0 {
+		return 0, &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("unsupported version %d", *header.SchemaVersion)}
+	}
+	return *header.SchemaVersion, nil
+}
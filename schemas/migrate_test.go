@@ -0,0 +1,72 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMigrateCurrentVersion(t *testing.T) {
+	for _, raw := range []string{
+		`{"tool":"search","parameters":{"q":"go"}}`,
+		`{"tool":"search","parameters":{"q":"go"},"schemaVersion":1}`,
+	} {
+		m, err := Migrate(json.RawMessage(raw))
+		if err != nil {
+			t.Fatalf("Migrate(%s): %v", raw, err)
+		}
+		if m.Tool != "search" || m.Parameters["q"] != "go" || m.SchemaVersion != CurrentSchemaVersion {
+			t.Errorf("Migrate(%s) = %+v", raw, m)
+		}
+		if got := m.Fingerprint(); got != (McpExecute{Tool: "search", Parameters: map[string]any{"q": "go"}}).Fingerprint() {
+			t.Errorf("explicit current version changed the fingerprint")
+		}
+	}
+
+	var verr *ValidationError
+	if _, err := Migrate(json.RawMessage(`{"tool":"search","schemaVersion":99}`)); !errors.As(err, &verr) || verr.Field != "schemaVersion" {
+		t.Errorf("expected schemaVersion error for a future version, got %v", err)
+	}
+	if err := (McpExecute{Tool: "search", SchemaVersion: 2}).Validate(); err == nil {
+		t.Error("Validate accepted an unsupported version")
+	}
+	if (McpExecute{}).Version() != CurrentSchemaVersion {
+		t.Error("Version did not default to CurrentSchemaVersion")
+	}
+}
+
+func TestMigrateChain(t *testing.T) {
+	// v1 payloads called the parameters "args"; v2 renamed them.
+	chain := map[int]Migration{
+		1: func(raw json.RawMessage) (json.RawMessage, error) {
+			var p map[string]any
+			if err := json.Unmarshal(raw, &p); err != nil {
+				return nil, err
+			}
+			p["parameters"], p["schemaVersion"] = p["args"], 2
+			delete(p, "args")
+			return json.Marshal(p)
+		},
+		2: func(raw json.RawMessage) (json.RawMessage, error) {
+			return raw, nil
+		},
+	}
+	out, err := migrateRaw(json.RawMessage(`{"tool":"search","args":{"q":"go"},"schemaVersion":1}`), 2, func(v int) Migration { return chain[v] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"parameters":{"q":"go"},"schemaVersion":2,"tool":"search"}`; string(out) != want {
+		t.Errorf("migrateRaw = %s, want %s", out, want)
+	}
+
+	stuck := func(int) Migration {
+		return func(raw json.RawMessage) (json.RawMessage, error) { return raw, nil }
+	}
+	if _, err := migrateRaw(json.RawMessage(`{"tool":"t","schemaVersion":1}`), 2, stuck); err == nil {
+		t.Error("expected error for a migration that does not advance")
+	}
+	none := func(int) Migration { return nil }
+	if _, err := migrateRaw(json.RawMessage(`{"tool":"t","schemaVersion":1}`), 2, none); err == nil {
+		t.Error("expected error for a missing migration")
+	}
+}
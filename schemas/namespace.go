@@ -0,0 +1,26 @@
+package schemas
+
+import "strings"
+
+// NamespaceSeparator separates a namespace from a tool name, as in "github.search".
+const NamespaceSeparator = "."
+
+// Qualify returns a copy of the request whose tool name is prefixed with
+// namespace, for routing to one of several federated servers. An empty
+// namespace leaves the name unchanged.
+func (m McpExecute) Qualify(namespace string) McpExecute {
+	if namespace != "" {
+		m.Tool = namespace + NamespaceSeparator + m.Tool
+	}
+	return m
+}
+
+// Split returns the namespace and unqualified tool name. The namespace is
+// everything before the first separator, so "fs.files.read" splits into
+// "fs" and "files.read"; an unqualified name has an empty namespace.
+func (m McpExecute) Split() (namespace, tool string) {
+	if ns, name, ok := strings.Cut(m.Tool, NamespaceSeparator); ok && ns != "" && name != "" {
+		return ns, name
+	}
+	return "", m.Tool
+}
@@ -0,0 +1,25 @@
+package schemas
+
+import "testing"
+
+func TestQualifyAndSplit(t *testing.T) {
+	m := McpExecute{Tool: "files.read", Parameters: map[string]any{"path": "/"}}
+	q := m.Qualify("fs")
+	if q.Tool != "fs.files.read" || m.Tool != "files.read" {
+		t.Errorf("Qualify = %q, original %q", q.Tool, m.Tool)
+	}
+	if got := m.Qualify(""); got.Tool != "files.read" {
+		t.Errorf("Qualify(\"\") = %q", got.Tool)
+	}
+	tests := []struct{ tool, ns, name string }{
+		{"fs.files.read", "fs", "files.read"},
+		{"search", "", "search"},
+		{".hidden", "", ".hidden"},
+		{"trailing.", "", "trailing."},
+	}
+	for _, tt := range tests {
+		if ns, name := (McpExecute{Tool: tt.tool}).Split(); ns != tt.ns || name != tt.name {
+			t.Errorf("Split(%q) = %q, %q; want %q, %q", tt.tool, ns, name, tt.ns, tt.name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package schemas
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxExactFloat is the largest integer magnitude a float64 represents exactly.
+const maxExactFloat = 1 << 53
+
+// Normalize returns a copy of the request with parameter values coerced to
+// the types declared by the tool's input schema where this loses nothing:
+// numeric strings become numbers and "true"/"false" strings become
+// booleans, and explicit nulls are dropped from optional properties that do
+// not allow null. Values that cannot be coerced exactly, such as "1.5" for
+// an integer or "yes" for a boolean, are reported as FieldErrors with rule
+// "coerce". Values the schema does not constrain are left untouched.
+func (m McpExecute) Normalize(def ToolDefinition) (McpExecute, error) {
+	s, err := def.parseSchema()
+	if err != nil {
+		return McpExecute{}, err
+	}
+	out := m.Clone()
+	var v schemaValidator
+	v.normalizeObject(s, "", out.Parameters)
+	if len(v.violations) > 0 {
+		return McpExecute{}, v.violations
+	}
+	return out, nil
+}
+
+// normalizeObject coerces the properties of obj in place.
+func (v *schemaValidator) normalizeObject(s *jsonSchema, path string, obj map[string]any) {
+	required := make(map[string]struct{}, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = struct{}{}
+	}
+	for _, k := range sortedKeys(obj) {
+		prop, ok := s.Properties[k]
+		if !ok {
+			continue
+		}
+		value := obj[k]
+		if value == nil {
+			if _, req := required[k]; !req && !kindMatches(kindNull, prop.types()) && len(prop.types()) > 0 {
+				delete(obj, k)
+			}
+			continue
+		}
+		obj[k] = v.normalizeValue(prop, joinPath(path, k), value)
+	}
+}
+
+// normalizeValue returns value coerced to s. Typed containers such as
+// []string or map[string]string are copied to []any and map[string]any so
+// their elements can be coerced.
+func (v *schemaValidator) normalizeValue(s *jsonSchema, path string, value any) any {
+	types := s.types()
+	kind := jsonKind(value)
+	if len(types) > 0 && !kindMatches(kind, types) && kind == kindString {
+		str, _ := stringValue(value)
+		return v.coerceString(types, path, str)
+	}
+	switch kind {
+	case kindObject:
+		obj := toObject(value)
+		v.normalizeObject(s, path, obj)
+		return obj
+	case kindArray:
+		if _, ok := value.([]byte); ok || s.Items == nil {
+			return value
+		}
+		arr := toArray(value)
+		for i, item := range arr {
+			if item != nil {
+				arr[i] = v.normalizeValue(s.Items, path+"["+strconv.Itoa(i)+"]", item)
+			}
+		}
+		return arr
+	}
+	return value
+}
+
+// coerceString converts s to the first declared type it can represent
+// exactly, recording a violation if there is none.
+func (v *schemaValidator) coerceString(types []string, path, s string) any {
+	trimmed := strings.TrimSpace(s)
+	for _, t := range types {
+		switch t {
+		case kindInteger:
+			n, err := strconv.ParseInt(trimmed, 10, 64)
+			if err == nil && n >= -maxExactFloat && n <= maxExactFloat {
+				return float64(n)
+			}
+		case kindNumber:
+			f, err := strconv.ParseFloat(trimmed, 64)
+			if err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+				return f
+			}
+		case kindBoolean:
+			switch strings.ToLower(trimmed) {
+			case "true":
+				return true
+			case "false":
+				return false
+			}
+		}
+	}
+	v.fail(path, "coerce", "cannot convert %q to %s", s, joinTypes(types))
+	return s
+}
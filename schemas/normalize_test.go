@@ -0,0 +1,113 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var deployTool = ToolDefinition{
+	Name: "deploy",
+	InputSchema: []byte(`{
+		"type": "object",
+		"properties": {
+			"service":  {"type": "string"},
+			"replicas": {"type": "integer"},
+			"ratio":    {"type": "number"},
+			"dryRun":   {"type": "boolean"},
+			"note":     {"type": "string"},
+			"parent":   {"type": ["string", "null"]},
+			"ports":    {"type": "array", "items": {"type": "integer"}},
+			"limits":   {"type": "object", "properties": {"cpu": {"type": "number"}}}
+		},
+		"required": ["service"]
+	}`),
+}
+
+func TestNormalize(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service":  "api",
+		"replicas": "3",
+		"ratio":    " 0.5 ",
+		"dryRun":   "TRUE",
+		"note":     nil,
+		"parent":   nil,
+		"ports":    []any{"80", float64(443)},
+		"limits":   map[string]any{"cpu": "1.5"},
+		"extra":    "7",
+	}}
+	got, err := m.Normalize(deployTool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"service":  "api",
+		"replicas": float64(3),
+		"ratio":    0.5,
+		"dryRun":   true,
+		"parent":   nil,
+		"ports":    []any{float64(80), float64(443)},
+		"limits":   map[string]any{"cpu": 1.5},
+		"extra":    "7",
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("Normalize =\n%#v\nwant\n%#v", got.Parameters, want)
+	}
+	if m.Parameters["replicas"] != "3" {
+		t.Error("Normalize mutated the original request")
+	}
+	if err := got.ValidateAgainst(deployTool); err != nil {
+		t.Errorf("normalized request does not validate: %v", err)
+	}
+}
+
+func TestNormalizeLossy(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service":  "api",
+		"replicas": "1.5",
+		"dryRun":   "yes",
+		"ports":    []any{"99999999999999999999"},
+	}}
+	_, err := m.Normalize(deployTool)
+	var ferrs FieldErrors
+	if !errors.As(err, &ferrs) || len(ferrs) != 3 {
+		t.Fatalf("expected 3 FieldErrors, got %v", err)
+	}
+	for _, fe := range ferrs {
+		if fe.Rule != "coerce" {
+			t.Errorf("%s: rule = %q", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestNormalizeTypedContainers(t *testing.T) {
+	ports := []string{"80", "443"}
+	limits := map[string]string{"cpu": "1.5"}
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service": "api",
+		"ports":   ports,
+		"limits":  limits,
+	}}
+	got, err := m.Normalize(deployTool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{float64(80), float64(443)}; !reflect.DeepEqual(got.Parameters["ports"], want) {
+		t.Errorf("ports = %#v, want %#v", got.Parameters["ports"], want)
+	}
+	if want := map[string]any{"cpu": 1.5}; !reflect.DeepEqual(got.Parameters["limits"], want) {
+		t.Errorf("limits = %#v, want %#v", got.Parameters["limits"], want)
+	}
+	if ports[0] != "80" || limits["cpu"] != "1.5" {
+		t.Error("Normalize mutated the original containers")
+	}
+	if err := got.ValidateAgainst(deployTool); err != nil {
+		t.Errorf("normalized request does not validate: %v", err)
+	}
+
+	bad := McpExecute{Tool: "deploy", Parameters: map[string]any{"service": "api", "ports": []string{"http"}}}
+	var ferrs FieldErrors
+	if _, err := bad.Normalize(deployTool); !errors.As(err, &ferrs) || len(ferrs) != 1 || ferrs[0].Path != "ports[0]" {
+		t.Errorf("typed slice coercion failure = %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package schemas
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Limits that keep span attributes low-cardinality.
+const (
+	maxSpanParams      = 16
+	maxSpanStringBytes = 128
+)
+
+// SpanAttributes returns OpenTelemetry attributes describing the call: the
+// tool name, the number of parameters and, for at most the first 16 keys in
+// sorted order, either the scalar value or, for objects and arrays, their
+// element count. Strings longer than 128 bytes are omitted, and keys listed
+// in DefaultSensitiveKeys are left out entirely.
+func (m McpExecute) SpanAttributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("mcp.tool.name", m.Tool),
+		attribute.Int("mcp.params.count", len(m.Parameters)),
+	}
+	keys := make([]string, 0, len(m.Parameters))
+	for _, k := range sortedKeys(m.Parameters) {
+		if !sensitiveKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) > maxSpanParams {
+		keys = keys[:maxSpanParams]
+	}
+	for _, k := range keys {
+		name := "mcp.param." + k
+		v := m.Parameters[k]
+		switch kind := jsonKind(v); kind {
+		case kindString:
+			if s, _ := stringValue(v); len(s) <= maxSpanStringBytes {
+				attrs = append(attrs, attribute.String(name, s))
+			}
+		case kindBoolean:
+			b, _ := boolValue(v)
+			attrs = append(attrs, attribute.Bool(name, b))
+		case kindInteger, kindNumber:
+			if n, ok := asInt64(v); ok {
+				attrs = append(attrs, attribute.Int64(name, n))
+			} else if f, ok := toFloat(v); ok {
+				attrs = append(attrs, attribute.Float64(name, f))
+			}
+		case kindObject:
+			attrs = append(attrs, attribute.Int(name+".count", len(toObject(v))))
+		case kindArray:
+			attrs = append(attrs, attribute.Int(name+".count", len(toArray(v))))
+		}
+	}
+	return attrs
+}
+
+// sensitiveKey reports whether key matches one of DefaultSensitiveKeys,
+// compared case-insensitively.
+func sensitiveKey(key string) bool {
+	for _, k := range DefaultSensitiveKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
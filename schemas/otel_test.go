@@ -0,0 +1,64 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSpanAttributes(t *testing.T) {
+	name, on := "api", true
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service":  &name,
+		"dryRun":   &on,
+		"replicas": 3,
+		"ratio":    json.Number("0.5"),
+		"password": "hunter2",
+		"Token":    "abc",
+		"notes":    strings.Repeat("x", maxSpanStringBytes+1),
+		"labels":   map[string]string{"team": "core"},
+		"ports":    []int{80, 443},
+		"parent":   nil,
+	}}
+	got := map[attribute.Key]attribute.Value{}
+	for _, kv := range m.SpanAttributes() {
+		got[kv.Key] = kv.Value
+		if k := string(kv.Key); strings.Contains(k, "password") || strings.Contains(k, "Token") {
+			t.Errorf("sensitive key exported: %s", k)
+		}
+	}
+	want := map[attribute.Key]any{
+		"mcp.tool.name":          "deploy",
+		"mcp.params.count":       int64(10),
+		"mcp.param.service":      "api",
+		"mcp.param.dryRun":       true,
+		"mcp.param.replicas":     int64(3),
+		"mcp.param.ratio":        0.5,
+		"mcp.param.labels.count": int64(1),
+		"mcp.param.ports.count":  int64(2),
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d attributes, want %d: %v", len(got), len(want), got)
+	}
+	for k, w := range want {
+		if v, ok := got[k]; !ok || !reflect.DeepEqual(v.AsInterface(), w) {
+			t.Errorf("%s = %v, want %v", k, v.AsInterface(), w)
+		}
+	}
+}
+
+func TestSpanAttributesBounded(t *testing.T) {
+	params := map[string]any{}
+	for i := 0; i < 40; i++ {
+		params[fmt.Sprintf("k%02d", i)] = i
+	}
+	params["apiKey"] = "secret"
+	attrs := McpExecute{Tool: "t", Parameters: params}.SpanAttributes()
+	if len(attrs) != 2+maxSpanParams {
+		t.Errorf("got %d attributes, want %d", len(attrs), 2+maxSpanParams)
+	}
+}
@@ -0,0 +1,251 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// patchOperation is a single RFC 6902 JSON Patch operation.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyPatch returns a copy of the request with the RFC 6902 JSON Patch
+// applied to its parameters. Paths are JSON Pointers rooted at the
+// parameters object, so "/config/timeout" addresses
+// Parameters["config"]["timeout"]. The add, remove, replace, move, copy and
+// test operations are supported; if any operation fails the request is
+// left unchanged and an error is returned.
+func (m McpExecute) ApplyPatch(patch json.RawMessage) (McpExecute, error) {
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return McpExecute{}, fmt.Errorf("invalid JSON Patch: %w", err)
+	}
+	var doc any = map[string]any{}
+	if m.Parameters != nil {
+		doc = plainValue(m.Parameters)
+	}
+	for i, op := range ops {
+		var err error
+		if doc, err = applyPatchOperation(doc, op); err != nil {
+			return McpExecute{}, fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	params, ok := doc.(map[string]any)
+	if !ok {
+		return McpExecute{}, fmt.Errorf("patch replaced parameters with a non-object value")
+	}
+	out := m
+	out.Parameters = params
+	return out, nil
+}
+
+func applyPatchOperation(doc any, op patchOperation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	value := func() (any, error) {
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		var v any
+		if err := json.Unmarshal(op.Value, &v); err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		return v, nil
+	}
+	switch op.Op {
+	case "add", "replace":
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "replace" && len(path) > 0 {
+			if _, err := pointerGet(doc, path); err != nil {
+				return nil, err
+			}
+			doc, err = pointerRemove(doc, path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return pointerAdd(doc, path, v)
+	case "remove":
+		return pointerRemove(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		v, err := pointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if len(path) > len(from) && strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("cannot move %s into its own child", op.From)
+			}
+			if doc, err = pointerRemove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			v = cloneValue(v)
+		}
+		return pointerAdd(doc, path, v)
+	case "test":
+		want, err := value()
+		if err != nil {
+			return nil, err
+		}
+		got, err := pointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !valuesEqual(got, want) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unsupported operation %q", op.Op)
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON Pointer %q", p)
+	}
+	toks := strings.Split(p[1:], "/")
+	for i, t := range toks {
+		toks[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return toks, nil
+}
+
+func arrayIndex(tok string, n int, allowEnd bool) (int, error) {
+	if allowEnd && tok == "-" {
+		return n, nil
+	}
+	i, err := strconv.Atoi(tok)
+	if err != nil || i < 0 || (tok != "0" && strings.HasPrefix(tok, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+	limit := n - 1
+	if allowEnd {
+		limit = n
+	}
+	if i > limit {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	cur := doc
+	for _, tok := range path {
+		switch node := cur.(type) {
+		case map[string]any:
+			v, ok := node[tok]
+			if !ok {
+				return nil, fmt.Errorf("path not found")
+			}
+			cur = v
+		case []any:
+			i, err := arrayIndex(tok, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			cur = node[i]
+		default:
+			return nil, fmt.Errorf("path not found")
+		}
+	}
+	return cur, nil
+}
+
+// pointerUpdate applies fn to the container holding the last token of path
+// and stores the updated container back into its parent.
+func pointerUpdate(doc any, path []string, fn func(parent any, tok string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	switch node := doc.(type) {
+	case map[string]any:
+		child, ok := node[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path not found")
+		}
+		updated, err := pointerUpdate(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[path[0]] = updated
+		return node, nil
+	case []any:
+		i, err := arrayIndex(path[0], len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := pointerUpdate(node[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = updated
+		return node, nil
+	}
+	return nil, fmt.Errorf("path not found")
+}
+
+func pointerAdd(doc any, path []string, v any) (any, error) {
+	if len(path) == 0 {
+		return v, nil
+	}
+	return pointerUpdate(doc, path, func(parent any, tok string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[tok] = v
+			return node, nil
+		case []any:
+			i, err := arrayIndex(tok, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = v
+			return node, nil
+		}
+		return nil, fmt.Errorf("path not found")
+	})
+}
+
+func pointerRemove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the parameters object")
+	}
+	return pointerUpdate(doc, path, func(parent any, tok string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			if _, ok := node[tok]; !ok {
+				return nil, fmt.Errorf("path not found")
+			}
+			delete(node, tok)
+			return node, nil
+		case []any:
+			i, err := arrayIndex(tok, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:i], node[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("path not found")
+	})
+}
@@ -0,0 +1,73 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service": "api",
+		"config":  map[string]any{"timeout": float64(30), "a/b": "slash"},
+		"regions": []any{"eu", "us"},
+		"tmp":     true,
+	}}
+	patch := []byte(`[
+		{"op": "test", "path": "/service", "value": "api"},
+		{"op": "replace", "path": "/config/timeout", "value": 60},
+		{"op": "add", "path": "/regions/1", "value": "ap"},
+		{"op": "add", "path": "/regions/-", "value": "sa"},
+		{"op": "remove", "path": "/tmp"},
+		{"op": "copy", "from": "/config", "path": "/backup"},
+		{"op": "move", "from": "/config/a~1b", "path": "/moved"}
+	]`)
+	got, err := m.ApplyPatch(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"service": "api",
+		"config":  map[string]any{"timeout": float64(60)},
+		"backup":  map[string]any{"timeout": float64(60), "a/b": "slash"},
+		"regions": []any{"eu", "ap", "us", "sa"},
+		"moved":   "slash",
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("ApplyPatch =\n%#v\nwant\n%#v", got.Parameters, want)
+	}
+	if m.Parameters["tmp"] != true || len(m.Parameters["regions"].([]any)) != 2 {
+		t.Error("ApplyPatch mutated the original request")
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{"a": map[string]any{"b": 1}, "list": []any{1}}}
+	for _, patch := range []string{
+		`{"op": "add"}`,
+		`[{"op": "remove", "path": "/missing"}]`,
+		`[{"op": "replace", "path": "/missing", "value": 1}]`,
+		`[{"op": "add", "path": "/list/5", "value": 1}]`,
+		`[{"op": "add", "path": "/a/b/c", "value": 1}]`,
+		`[{"op": "add", "path": "/x"}]`,
+		`[{"op": "move", "from": "/a", "path": "/a/child"}]`,
+		`[{"op": "test", "path": "/list/0", "value": 2}]`,
+		`[{"op": "replace", "path": "", "value": [1]}]`,
+		`[{"op": "bogus", "path": "/a"}]`,
+		`[{"op": "remove", "path": "a"}]`,
+	} {
+		if _, err := m.ApplyPatch([]byte(patch)); err == nil {
+			t.Errorf("ApplyPatch(%s) succeeded", patch)
+		}
+	}
+}
+
+func TestApplyPatchReplaceRoot(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{"a": 1}}
+	got, err := m.ApplyPatch([]byte(`[{"op": "replace", "path": "", "value": {"b": 2}}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Parameters, map[string]any{"b": float64(2)}) {
+		t.Errorf("got %#v", got.Parameters)
+	}
+}
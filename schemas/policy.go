@@ -0,0 +1,65 @@
+package schemas
+
+import "time"
+
+// MaxRetryDelay caps the delay returned by McpExecutePolicy.RetryDelay.
+const MaxRetryDelay = 30 * time.Second
+
+// McpExecutePolicy defines the reliability controls applied to an MCP tool execution.
+type McpExecutePolicy struct {
+	TimeoutMs      int64 `json:"timeoutMs,omitempty" doc:"The per-attempt timeout in milliseconds; zero means no timeout."`
+	MaxRetries     int   `json:"maxRetries,omitempty" doc:"The number of retries after the first attempt fails."`
+	RetryBackoffMs int64 `json:"retryBackoffMs,omitempty" doc:"The delay before the first retry in milliseconds, doubled on each subsequent retry."`
+}
+
+// McpExecuteWithPolicy defines an MCP tool execution together with its policy.
+type McpExecuteWithPolicy struct {
+	McpExecute
+	Policy McpExecutePolicy `json:"policy" doc:"The reliability controls for the execution."`
+}
+
+// Validate checks that no policy value is negative.
+func (p McpExecutePolicy) Validate() error {
+	switch {
+	case p.TimeoutMs < 0:
+		return &ValidationError{Field: "policy.timeoutMs", Message: "must not be negative"}
+	case p.MaxRetries < 0:
+		return &ValidationError{Field: "policy.maxRetries", Message: "must not be negative"}
+	case p.RetryBackoffMs < 0:
+		return &ValidationError{Field: "policy.retryBackoffMs", Message: "must not be negative"}
+	}
+	return nil
+}
+
+// Timeout returns the per-attempt timeout, or zero if none is set.
+func (p McpExecutePolicy) Timeout() time.Duration {
+	return time.Duration(p.TimeoutMs) * time.Millisecond
+}
+
+// RetryDelay returns how long to wait before the given retry, numbered from
+// one. The delay starts at RetryBackoffMs and doubles with each retry, up
+// to MaxRetryDelay.
+func (p McpExecutePolicy) RetryDelay(attempt int) time.Duration {
+	if attempt < 1 || p.RetryBackoffMs <= 0 {
+		return 0
+	}
+	if p.RetryBackoffMs >= int64(MaxRetryDelay/time.Millisecond) {
+		return MaxRetryDelay
+	}
+	delay := time.Duration(p.RetryBackoffMs) * time.Millisecond
+	for i := 1; i < attempt; i++ {
+		if delay >= MaxRetryDelay/2 {
+			return MaxRetryDelay
+		}
+		delay *= 2
+	}
+	return min(delay, MaxRetryDelay)
+}
+
+// Validate checks both the execution request and its policy.
+func (e McpExecuteWithPolicy) Validate() error {
+	if err := e.McpExecute.Validate(); err != nil {
+		return err
+	}
+	return e.Policy.Validate()
+}
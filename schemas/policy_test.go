@@ -0,0 +1,46 @@
+package schemas
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	p := McpExecutePolicy{MaxRetries: 10, RetryBackoffMs: 100}
+	tests := map[int]time.Duration{
+		0:  0,
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		9:  25600 * time.Millisecond,
+		10: MaxRetryDelay,
+		99: MaxRetryDelay,
+	}
+	for attempt, want := range tests {
+		if got := p.RetryDelay(attempt); got != want {
+			t.Errorf("RetryDelay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	for _, backoff := range []int64{30000, math.MaxInt64 / 1000, math.MaxInt64} {
+		p := McpExecutePolicy{RetryBackoffMs: backoff}
+		for _, attempt := range []int{1, 2} {
+			if got := p.RetryDelay(attempt); got != MaxRetryDelay {
+				t.Errorf("RetryBackoffMs=%d: RetryDelay(%d) = %v, want %v", backoff, attempt, got, MaxRetryDelay)
+			}
+		}
+	}
+}
+
+func TestMcpExecuteWithPolicyValidate(t *testing.T) {
+	ok := McpExecuteWithPolicy{McpExecute: McpExecute{Tool: "t"}, Policy: McpExecutePolicy{TimeoutMs: 1000}}
+	if err := ok.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []McpExecutePolicy{{TimeoutMs: -1}, {MaxRetries: -1}, {RetryBackoffMs: -1}} {
+		bad := McpExecuteWithPolicy{McpExecute: McpExecute{Tool: "t"}, Policy: p}
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected error for %+v", p)
+		}
+	}
+}
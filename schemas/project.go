@@ -0,0 +1,33 @@
+package schemas
+
+// Project returns a deep copy of the request containing only the top-level
+// parameters named in allowed. Names that are not present are ignored. The
+// original is not modified.
+func (m McpExecute) Project(allowed []string) McpExecute {
+	return m.filterParams(allowed, true)
+}
+
+// Omit returns a deep copy of the request without the top-level parameters
+// named in denied. The original is not modified.
+func (m McpExecute) Omit(denied []string) McpExecute {
+	return m.filterParams(denied, false)
+}
+
+// filterParams copies the parameters whose membership in names equals keep.
+func (m McpExecute) filterParams(names []string, keep bool) McpExecute {
+	out := m
+	if m.Parameters == nil {
+		return out
+	}
+	set := make(map[string]struct{}, len(names))
+	for _, k := range names {
+		set[k] = struct{}{}
+	}
+	out.Parameters = make(map[string]any, len(m.Parameters))
+	for k, v := range m.Parameters {
+		if _, ok := set[k]; ok == keep {
+			out.Parameters[k] = cloneValue(v)
+		}
+	}
+	return out
+}
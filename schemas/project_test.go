@@ -0,0 +1,38 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProjectAndOmit(t *testing.T) {
+	m := McpExecute{Tool: "search", Parameters: map[string]any{
+		"query":    "go",
+		"filters":  map[string]any{"lang": "en"},
+		"_routeTo": "shard-2",
+		"_traceID": "abc",
+	}}
+
+	p := m.Project([]string{"query", "filters", "missing"})
+	want := map[string]any{"query": "go", "filters": map[string]any{"lang": "en"}}
+	if p.Tool != "search" || !reflect.DeepEqual(p.Parameters, want) {
+		t.Errorf("Project = %+v", p)
+	}
+	p.Parameters["filters"].(map[string]any)["lang"] = "de"
+	if m.Parameters["filters"].(map[string]any)["lang"] != "en" {
+		t.Error("Project aliased nested parameters")
+	}
+
+	o := m.Omit([]string{"_routeTo", "_traceID"})
+	want = map[string]any{"query": "go", "filters": map[string]any{"lang": "en"}}
+	if !reflect.DeepEqual(o.Parameters, want) {
+		t.Errorf("Omit = %+v", o.Parameters)
+	}
+	if len(m.Parameters) != 4 {
+		t.Error("Omit mutated the original")
+	}
+
+	if got := (McpExecute{Tool: "t"}).Project([]string{"a"}); got.Parameters != nil {
+		t.Errorf("Project of nil parameters = %v", got.Parameters)
+	}
+}
@@ -0,0 +1,45 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sjoerd2025/antigravity-jules-orchestration/schemas/mcppb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToProto converts the execution request to its protobuf message. Parameters
+// are carried as a structpb.Struct, so numbers become float64 on the wire.
+// A SchemaVersion that is negative or does not fit in an int32 is rejected
+// with a ValidationError.
+func ToProto(m McpExecute) (*mcppb.McpExecute, error) {
+	if v := m.SchemaVersion; v < 0 || v > math.MaxInt32 {
+		return nil, fmt.Errorf("tool %q: %w", m.Tool, &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("unsupported version %d", v)})
+	}
+	p := &mcppb.McpExecute{Tool: m.Tool, SchemaVersion: int32(m.SchemaVersion)}
+	if m.Parameters != nil {
+		params, err := nativeValue(m.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("tool %q: %w", m.Tool, err)
+		}
+		s, err := structpb.NewStruct(params.(map[string]any))
+		if err != nil {
+			return nil, fmt.Errorf("tool %q: %w", m.Tool, err)
+		}
+		p.Parameters = s
+	}
+	return p, nil
+}
+
+// FromProto converts a protobuf message to an execution request.
+func FromProto(p *mcppb.McpExecute) (McpExecute, error) {
+	if p == nil {
+		return McpExecute{}, errors.New("nil McpExecute message")
+	}
+	m := McpExecute{Tool: p.GetTool(), SchemaVersion: int(p.GetSchemaVersion())}
+	if s := p.GetParameters(); s != nil {
+		m.Parameters = s.AsMap()
+	}
+	return m, nil
+}
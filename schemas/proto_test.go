@@ -0,0 +1,105 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/sjoerd2025/antigravity-jules-orchestration/schemas/mcppb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"replicas": json.Number("3"),
+		"ratio":    json.Number("0.25"),
+		"labels":   map[string]string{"team": "core"},
+		"matrix":   []any{[]any{1, 2}, []string{"a"}},
+		"enabled":  true,
+		"parent":   nil,
+	}}
+	p, err := ToProto(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := proto.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded mcppb.McpExecute
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FromProto(&decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"replicas": float64(3),
+		"ratio":    0.25,
+		"labels":   map[string]any{"team": "core"},
+		"matrix":   []any{[]any{float64(1), float64(2)}, []any{"a"}},
+		"enabled":  true,
+		"parent":   nil,
+	}
+	if got.Tool != "deploy" || !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("FromProto(ToProto(m)) = %#v", got)
+	}
+	if !proto.Equal(p, &decoded) {
+		t.Error("message did not survive wire encoding")
+	}
+}
+
+func TestProtoNilParameters(t *testing.T) {
+	p, err := ToProto(McpExecute{Tool: "ping"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.GetParameters() != nil {
+		t.Errorf("nil parameters encoded as %v", p.GetParameters())
+	}
+	got, err := FromProto(p)
+	if err != nil || got.Tool != "ping" || got.Parameters != nil {
+		t.Errorf("FromProto = %+v, %v", got, err)
+	}
+	if _, err := FromProto(nil); err == nil {
+		t.Error("expected error for nil message")
+	}
+	if _, err := ToProto(McpExecute{Tool: "t", Parameters: map[string]any{"f": func() {}}}); err == nil {
+		t.Error("expected error for unsupported parameter type")
+	}
+}
+
+func TestProtoSchemaVersion(t *testing.T) {
+	p, err := ToProto(McpExecute{Tool: "t", SchemaVersion: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := proto.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded mcppb.McpExecute
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FromProto(&decoded)
+	if err != nil || got.SchemaVersion != 1 {
+		t.Errorf("FromProto = %+v, %v", got, err)
+	}
+	fd := decoded.ProtoReflect().Descriptor().Fields().ByName("schema_version")
+	if fd == nil || fd.Number() != 3 || fd.JSONName() != "schemaVersion" {
+		t.Errorf("schema_version descriptor = %v", fd)
+	}
+}
+
+func TestProtoSchemaVersionRange(t *testing.T) {
+	var verr *ValidationError
+	for _, v := range []int64{-1, math.MaxInt32 + 1} {
+		if _, err := ToProto(McpExecute{Tool: "t", SchemaVersion: int(v)}); !errors.As(err, &verr) || verr.Field != "schemaVersion" {
+			t.Errorf("SchemaVersion %d: got %v", v, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Rate defines a token-bucket limit on tool executions.
+type Rate struct {
+	PerSecond float64 `json:"perSecond" doc:"The sustained number of executions per second; zero means unlimited."`
+	Burst     int     `json:"burst,omitempty" doc:"The number of executions allowed at once; values below one mean one."`
+}
+
+func (r Rate) unlimited() bool {
+	return r.PerSecond <= 0
+}
+
+func (r Rate) burst() float64 {
+	return float64(max(r.Burst, 1))
+}
+
+// RateLimitOptions configures a RateLimitedExecutor.
+type RateLimitOptions struct {
+	// Rates holds per-tool limits.
+	Rates map[string]Rate
+	// Default applies to tools without an entry in Rates.
+	Default Rate
+	// FailFast returns a *RateLimitedError instead of waiting for a token.
+	FailFast bool
+	// Now returns the current time; it defaults to time.Now.
+	Now func() time.Time
+}
+
+// RateLimitedError reports that a call was rejected by a RateLimitedExecutor.
+type RateLimitedError struct {
+	Tool       string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("tool %q rate limited, retry after %v", e.Tool, e.RetryAfter)
+}
+
+type tokenBucket struct {
+	rate   Rate
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens accrued since the last update.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.tokens+elapsed*b.rate.PerSecond, b.rate.burst())
+	}
+	b.last = now
+}
+
+// wait returns how long until a token is available.
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate.PerSecond * float64(time.Second))
+}
+
+// RateLimitedExecutor is an Executor that applies per-tool token-bucket
+// limits before delegating to another Executor. It is safe for concurrent use.
+type RateLimitedExecutor struct {
+	next     Executor
+	failFast bool
+	now      func() time.Time
+
+	mu      sync.Mutex
+	rates   map[string]Rate
+	def     Rate
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimitedExecutor returns a RateLimitedExecutor wrapping next.
+func NewRateLimitedExecutor(next Executor, opts RateLimitOptions) *RateLimitedExecutor {
+	e := &RateLimitedExecutor{
+		next:     next,
+		failFast: opts.FailFast,
+		now:      opts.Now,
+		rates:    make(map[string]Rate, len(opts.Rates)),
+		def:      opts.Default,
+		buckets:  make(map[string]*tokenBucket),
+	}
+	if e.now == nil {
+		e.now = time.Now
+	}
+	for tool, r := range opts.Rates {
+		e.rates[tool] = r
+	}
+	return e
+}
+
+// SetRate changes the limit for tool. Tokens already accrued are kept, up
+// to the new burst size.
+func (e *RateLimitedExecutor) SetRate(tool string, r Rate) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rates[tool] = r
+	if b, ok := e.buckets[tool]; ok {
+		b.refill(e.now())
+		b.rate = r
+		b.tokens = min(b.tokens, r.burst())
+	}
+}
+
+// Execute waits for, or in fail-fast mode requires, a token for the tool
+// before delegating to the wrapped Executor.
+func (e *RateLimitedExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	if err := e.acquire(ctx, m.Tool); err != nil {
+		return McpResult{}, err
+	}
+	return e.next.Execute(ctx, m)
+}
+
+func (e *RateLimitedExecutor) acquire(ctx context.Context, tool string) error {
+	e.mu.Lock()
+	b := e.bucket(tool)
+	if b == nil {
+		e.mu.Unlock()
+		return nil
+	}
+	b.refill(e.now())
+	wait := b.wait()
+	if wait > 0 && e.failFast {
+		e.mu.Unlock()
+		return &RateLimitedError{Tool: tool, RetryAfter: wait}
+	}
+	// Reserve the token now; the bucket goes negative while we wait so
+	// later callers queue behind us.
+	b.tokens--
+	e.mu.Unlock()
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		e.mu.Lock()
+		b.tokens++
+		e.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// bucket returns the bucket for tool, or nil if the tool is unlimited; e.mu
+// must be held.
+func (e *RateLimitedExecutor) bucket(tool string) *tokenBucket {
+	r, ok := e.rates[tool]
+	if !ok {
+		r = e.def
+	}
+	if r.unlimited() {
+		delete(e.buckets, tool)
+		return nil
+	}
+	b, ok := e.buckets[tool]
+	if !ok {
+		b = &tokenBucket{rate: r, tokens: r.burst(), last: e.now()}
+		e.buckets[tool] = b
+	}
+	b.rate = r
+	return b
+}
@@ -0,0 +1,71 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedExecutorFailFast(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		return McpResult{Tool: m.Tool}, nil
+	})
+	e := NewRateLimitedExecutor(next, RateLimitOptions{
+		Rates:    map[string]Rate{"search": {PerSecond: 1, Burst: 2}},
+		FailFast: true,
+		Now:      func() time.Time { return now },
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := e.Execute(ctx, McpExecute{Tool: "search"}); err != nil {
+			t.Fatalf("call %d within burst: %v", i, err)
+		}
+	}
+	_, err := e.Execute(ctx, McpExecute{Tool: "search"})
+	var rlErr *RateLimitedError
+	if !errors.As(err, &rlErr) || rlErr.RetryAfter != time.Second {
+		t.Fatalf("expected RateLimitedError with 1s retry, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if _, err := e.Execute(ctx, McpExecute{Tool: "search"}); err != nil {
+		t.Errorf("call after refill: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := e.Execute(ctx, McpExecute{Tool: "unlimited"}); err != nil {
+			t.Errorf("unlimited tool limited: %v", err)
+		}
+	}
+
+	e.SetRate("search", Rate{})
+	if _, err := e.Execute(ctx, McpExecute{Tool: "search"}); err != nil {
+		t.Errorf("call after removing limit: %v", err)
+	}
+}
+
+func TestRateLimitedExecutorBlocking(t *testing.T) {
+	next := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		return McpResult{Tool: m.Tool}, nil
+	})
+	e := NewRateLimitedExecutor(next, RateLimitOptions{Default: Rate{PerSecond: 50}})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := e.Execute(context.Background(), McpExecute{Tool: "t"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("3 calls at 50/s took %v, expected about 40ms of waiting", elapsed)
+	}
+
+	e.SetRate("t", Rate{PerSecond: 0.001})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := e.Execute(ctx, McpExecute{Tool: "t"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded while waiting, got %v", err)
+	}
+}
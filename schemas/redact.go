@@ -0,0 +1,26 @@
+package schemas
+
+import "strings"
+
+// redactedValue replaces the value of sensitive parameters.
+const redactedValue = "***"
+
+// Redacted returns a deep copy of the request in which every parameter whose
+// key matches one of sensitiveKeys, compared case-insensitively and at any
+// nesting depth, has its value replaced by "***". Objects and arrays of any
+// Go type are searched; typed containers are copied to map[string]any and
+// []any in the result. The original is not modified.
+func (m McpExecute) Redacted(sensitiveKeys []string) McpExecute {
+	keys := make(map[string]struct{}, len(sensitiveKeys))
+	for _, k := range sensitiveKeys {
+		keys[strings.ToLower(k)] = struct{}{}
+	}
+	out := m.Clone()
+	_ = rewriteObject("", out.Parameters, func(_, key string, v any) (any, bool, error) {
+		if _, sensitive := keys[strings.ToLower(key)]; sensitive && key != "" {
+			return redactedValue, true, nil
+		}
+		return v, false, nil
+	})
+	return out
+}
@@ -0,0 +1,55 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedacted(t *testing.T) {
+	m := McpExecute{
+		Tool: "render_deploy",
+		Parameters: map[string]any{
+			"API_KEY": "sk-123",
+			"service": "api",
+			"headers": map[string]any{"Authorization": "Bearer x", "accept": "json"},
+			"targets": []any{map[string]any{"token": "t1", "name": "a"}},
+		},
+	}
+	got := m.Redacted([]string{"api_key", "authorization", "TOKEN"})
+	want := map[string]any{
+		"API_KEY": "***",
+		"service": "api",
+		"headers": map[string]any{"Authorization": "***", "accept": "json"},
+		"targets": []any{map[string]any{"token": "***", "name": "a"}},
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("got %#v, want %#v", got.Parameters, want)
+	}
+	if m.Parameters["API_KEY"] != "sk-123" || m.Parameters["headers"].(map[string]any)["Authorization"] != "Bearer x" {
+		t.Error("Redacted mutated the original request")
+	}
+}
+
+func TestRedactedTypedContainers(t *testing.T) {
+	m := McpExecute{
+		Tool: "http_get",
+		Parameters: map[string]any{
+			"headers":  map[string]string{"Authorization": "Bearer X", "Accept": "json"},
+			"accounts": []map[string]string{{"user": "a", "password": "p1"}},
+			"backends": []map[string]any{{"url": "u", "apiKey": "k"}},
+		},
+	}
+	got := m.Redacted([]string{"authorization", "password", "apikey"})
+	want := map[string]any{
+		"headers":  map[string]any{"Authorization": "***", "Accept": "json"},
+		"accounts": []any{map[string]any{"user": "a", "password": "***"}},
+		"backends": []any{map[string]any{"url": "u", "apiKey": "***"}},
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("got %#v, want %#v", got.Parameters, want)
+	}
+	if m.Parameters["headers"].(map[string]string)["Authorization"] != "Bearer X" ||
+		m.Parameters["accounts"].([]map[string]string)[0]["password"] != "p1" {
+		t.Error("Redacted mutated the original request")
+	}
+}
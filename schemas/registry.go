@@ -0,0 +1,126 @@
+package schemas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ToolRegistry is an in-memory, concurrency-safe set of tool definitions
+// keyed by name. Create registries with NewToolRegistry; copies of a
+// registry share the same underlying definitions.
+type ToolRegistry struct {
+	mu    *sync.RWMutex
+	tools map[string]ToolDefinition
+}
+
+// NewToolRegistry returns an empty registry.
+func NewToolRegistry() ToolRegistry {
+	return ToolRegistry{mu: new(sync.RWMutex), tools: make(map[string]ToolDefinition)}
+}
+
+// Register adds a tool definition. It rejects empty and duplicate names.
+func (r ToolRegistry) Register(def ToolDefinition) error {
+	if def.Name == "" {
+		return &ValidationError{Field: "name", Message: "must not be empty"}
+	}
+	if r.mu == nil {
+		return fmt.Errorf("register %q: registry not initialized, use NewToolRegistry", def.Name)
+	}
+	if !def.SideEffect.Valid() {
+		return &ValidationError{Field: "sideEffect", Message: fmt.Sprintf("unknown side effect %q", def.SideEffect)}
+	}
+	if _, err := def.parseSchema(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.tools[def.Name]; dup {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("tool %q already registered", def.Name)}
+	}
+	r.tools[def.Name] = def
+	return nil
+}
+
+// RegisterNamespaced adds a tool definition under its qualified name
+// namespace.name, so that servers exposing tools of the same name can share
+// a registry.
+func (r ToolRegistry) RegisterNamespaced(namespace string, def ToolDefinition) error {
+	if namespace == "" || strings.Contains(namespace, NamespaceSeparator) {
+		return &ValidationError{Field: "namespace", Message: fmt.Sprintf("invalid namespace %q", namespace)}
+	}
+	if def.Name != "" {
+		def.Name = namespace + NamespaceSeparator + def.Name
+	}
+	return r.Register(def)
+}
+
+// Get returns the definition registered under name.
+func (r ToolRegistry) Get(name string) (ToolDefinition, bool) {
+	if r.mu == nil {
+		return ToolDefinition{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	def, ok := r.tools[name]
+	return def, ok
+}
+
+// List returns the registered tool names in sorted order.
+func (r ToolRegistry) List() []string {
+	if r.mu == nil {
+		return nil
+	}
+	r.mu.RLock()
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Lookup resolves a tool name to its definition. An exact match wins;
+// otherwise an unqualified name matches the single namespaced tool with
+// that name, and is reported as ambiguous if several namespaces define it.
+func (r ToolRegistry) Lookup(name string) (ToolDefinition, error) {
+	if def, ok := r.Get(name); ok {
+		return def, nil
+	}
+	if ns, _ := (McpExecute{Tool: name}).Split(); ns == "" && r.mu != nil {
+		var matches []string
+		r.mu.RLock()
+		for qualified := range r.tools {
+			if _, tool := (McpExecute{Tool: qualified}).Split(); tool == name && qualified != name {
+				matches = append(matches, qualified)
+			}
+		}
+		r.mu.RUnlock()
+		switch len(matches) {
+		case 1:
+			def, _ := r.Get(matches[0])
+			return def, nil
+		case 0:
+		default:
+			sort.Strings(matches)
+			return ToolDefinition{}, &ValidationError{Field: "tool", Message: fmt.Sprintf("ambiguous tool %q, qualify it as one of %s", name, strings.Join(matches, ", "))}
+		}
+	}
+	return ToolDefinition{}, &ValidationError{Field: "tool", Message: fmt.Sprintf("unknown tool %q", name)}
+}
+
+// ValidateKnown validates m and checks its parameters against the schema of
+// the registered tool it names, resolving the name as Lookup does.
+func (r ToolRegistry) ValidateKnown(m McpExecute) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	def, err := r.Lookup(m.Tool)
+	if err != nil {
+		return err
+	}
+	m.Tool = def.Name
+	return m.ValidateAgainst(def)
+}
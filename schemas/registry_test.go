@@ -0,0 +1,73 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToolRegistry(t *testing.T) {
+	reg := NewToolRegistry()
+	if err := reg.Register(searchTool); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Register(ToolDefinition{Name: "lint"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.Register(searchTool); err == nil {
+		t.Error("expected duplicate registration to fail")
+	}
+	if err := reg.Register(ToolDefinition{}); err == nil {
+		t.Error("expected empty name to fail")
+	}
+	if got := reg.List(); !reflect.DeepEqual(got, []string{"lint", "search"}) {
+		t.Errorf("List() = %v", got)
+	}
+	if _, ok := reg.Get("search"); !ok {
+		t.Error("Get(search) not found")
+	}
+
+	if err := reg.ValidateKnown(McpExecute{Tool: "search", Parameters: map[string]any{"query": "go"}}); err != nil {
+		t.Errorf("ValidateKnown valid call: %v", err)
+	}
+	if err := reg.ValidateKnown(McpExecute{Tool: "search"}); err == nil {
+		t.Error("expected missing required parameter to fail")
+	}
+	if err := reg.ValidateKnown(McpExecute{Tool: "unknown"}); err == nil {
+		t.Error("expected unknown tool to fail")
+	}
+}
+
+func TestToolRegistryNamespaces(t *testing.T) {
+	reg := NewToolRegistry()
+	for _, ns := range []string{"web", "code"} {
+		if err := reg.RegisterNamespaced(ns, searchTool); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := reg.RegisterNamespaced("fs", ToolDefinition{Name: "read"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.RegisterNamespaced("a.b", ToolDefinition{Name: "x"}); err == nil {
+		t.Error("expected namespace containing the separator to fail")
+	}
+	if got := reg.List(); !reflect.DeepEqual(got, []string{"code.search", "fs.read", "web.search"}) {
+		t.Errorf("List() = %v", got)
+	}
+
+	call := McpExecute{Tool: "search", Parameters: map[string]any{"query": "go"}}
+	if err := reg.ValidateKnown(call.Qualify("web")); err != nil {
+		t.Errorf("ValidateKnown qualified call: %v", err)
+	}
+	if err := reg.ValidateKnown(call); err == nil {
+		t.Error("expected ambiguous unqualified name to fail")
+	}
+	if err := reg.ValidateKnown(McpExecute{Tool: "read"}); err != nil {
+		t.Errorf("ValidateKnown unique unqualified name: %v", err)
+	}
+	if def, err := reg.Lookup("read"); err != nil || def.Name != "fs.read" {
+		t.Errorf("Lookup(read) = %v, %v", def.Name, err)
+	}
+	if _, err := reg.Lookup("fs.write"); err == nil {
+		t.Error("expected unknown qualified tool to fail")
+	}
+}
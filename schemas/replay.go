@@ -0,0 +1,220 @@
+package schemas
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Interaction is one recorded call, stored as a line of a JSONL recording.
+type Interaction struct {
+	Execute     McpExecute `json:"execute" doc:"The request sent to the executor."`
+	Result      McpResult  `json:"result" doc:"The result returned by the executor."`
+	Error       string     `json:"error,omitempty" doc:"The Go error returned by the executor, if any."`
+	ErrorKind   string     `json:"errorKind,omitempty" doc:"The sentinel the error wrapped: \"mcp\", \"deadline\", \"canceled\", \"closed\" or \"unexpectedCall\"."`
+	ErrorDetail *McpError  `json:"errorDetail,omitempty" doc:"The McpError the error wrapped, when ErrorKind is \"mcp\"."`
+}
+
+// replayErrorKinds maps the ErrorKind of a recording to the sentinel it
+// stands for.
+var replayErrorKinds = []struct {
+	kind string
+	err  error
+}{
+	{"deadline", context.DeadlineExceeded},
+	{"canceled", context.Canceled},
+	{"closed", ErrExecutorClosed},
+	{"unexpectedCall", ErrUnexpectedCall},
+}
+
+// recordError stores err in rec.
+func (rec *Interaction) recordError(err error) {
+	rec.Error = err.Error()
+	var merr *McpError
+	if errors.As(err, &merr) {
+		rec.ErrorKind = "mcp"
+		rec.ErrorDetail = merr
+		return
+	}
+	for _, k := range replayErrorKinds {
+		if errors.Is(err, k.err) {
+			rec.ErrorKind = k.kind
+			return
+		}
+	}
+}
+
+// replayedError is a recorded error: it has the recorded message and wraps
+// the recorded McpError or sentinel.
+type replayedError struct {
+	msg   string
+	cause error
+}
+
+func (e *replayedError) Error() string { return e.msg }
+func (e *replayedError) Unwrap() error { return e.cause }
+
+// replayError rebuilds the error stored in rec, or returns nil.
+func (rec Interaction) replayError() error {
+	if rec.Error == "" {
+		return nil
+	}
+	if rec.ErrorKind == "mcp" && rec.ErrorDetail != nil {
+		return &replayedError{msg: rec.Error, cause: rec.ErrorDetail}
+	}
+	for _, k := range replayErrorKinds {
+		if rec.ErrorKind == k.kind {
+			return &replayedError{msg: rec.Error, cause: k.err}
+		}
+	}
+	return errors.New(rec.Error)
+}
+
+// RecordingExecutor is an Executor that passes calls to another Executor
+// and writes each request and its outcome to w as one JSON line. It is safe
+// for concurrent use.
+type RecordingExecutor struct {
+	next Executor
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecordingExecutor returns a RecordingExecutor wrapping next and
+// writing to w.
+func NewRecordingExecutor(next Executor, w io.Writer) *RecordingExecutor {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &RecordingExecutor{next: next, enc: enc}
+}
+
+// Execute runs m and records the interaction. A failure to write the
+// recording is returned if the call itself succeeded.
+func (e *RecordingExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	r, err := e.next.Execute(ctx, m)
+	rec := Interaction{Execute: m, Result: r}
+	if err != nil {
+		rec.recordError(err)
+	}
+	e.mu.Lock()
+	werr := e.enc.Encode(rec)
+	e.mu.Unlock()
+	if err == nil && werr != nil {
+		return r, fmt.Errorf("record %s: %w", m.Tool, werr)
+	}
+	return r, err
+}
+
+// ReplayOptions configures a ReplayExecutor.
+type ReplayOptions struct {
+	// RequireAllConsumed makes Verify fail if any recorded interaction was
+	// not replayed.
+	RequireAllConsumed bool
+}
+
+// ReplayExecutor is an Executor that serves results from a recording made
+// by RecordingExecutor. Calls are matched by fingerprint; interactions
+// recorded more than once for the same request are replayed in recorded
+// order. It is safe for concurrent use.
+type ReplayExecutor struct {
+	requireAll bool
+
+	mu        sync.Mutex
+	pending   map[string][]Interaction
+	unmatched []McpExecute
+}
+
+// NewReplayExecutor reads a JSONL recording from r.
+func NewReplayExecutor(r io.Reader, opts ReplayOptions) (*ReplayExecutor, error) {
+	e := &ReplayExecutor{requireAll: opts.RequireAllConsumed, pending: make(map[string][]Interaction)}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, DefaultMaxDecodeBytes)
+	for line := 1; sc.Scan(); line++ {
+		if len(strings.TrimSpace(sc.Text())) == 0 {
+			continue
+		}
+		var rec Interaction
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		key := rec.Execute.Fingerprint()
+		e.pending[key] = append(e.pending[key], rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read recording: %w", err)
+	}
+	return e, nil
+}
+
+// Execute returns the next recorded outcome for m, or an error wrapping
+// ErrUnexpectedCall if none is left.
+func (e *ReplayExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	key := m.Fingerprint()
+	e.mu.Lock()
+	queue := e.pending[key]
+	if len(queue) == 0 {
+		e.unmatched = append(e.unmatched, m.Clone())
+		e.mu.Unlock()
+		return McpResult{}, fmt.Errorf("%w: %s", ErrUnexpectedCall, m)
+	}
+	rec := queue[0]
+	if len(queue) == 1 {
+		delete(e.pending, key)
+	} else {
+		e.pending[key] = queue[1:]
+	}
+	e.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return McpResult{}, err
+	}
+	return rec.Result, rec.replayError()
+}
+
+// Unmatched returns the calls that had no recorded interaction, in order.
+func (e *ReplayExecutor) Unmatched() []McpExecute {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]McpExecute(nil), e.unmatched...)
+}
+
+// Remaining returns the number of recorded interactions not yet replayed.
+func (e *ReplayExecutor) Remaining() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := 0
+	for _, queue := range e.pending {
+		n += len(queue)
+	}
+	return n
+}
+
+// Verify reports unmatched calls and, if RequireAllConsumed is set,
+// recorded interactions that were never replayed.
+func (e *ReplayExecutor) Verify() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var problems []string
+	for _, m := range e.unmatched {
+		problems = append(problems, "unmatched call "+m.String())
+	}
+	if e.requireAll {
+		var unused []string
+		for _, queue := range e.pending {
+			for _, rec := range queue {
+				unused = append(unused, "unconsumed interaction "+rec.Execute.String())
+			}
+		}
+		sort.Strings(unused)
+		problems = append(problems, unused...)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("replay: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package schemas
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	live := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		if m.Tool == "down" {
+			return McpResult{}, errors.New("connection refused")
+		}
+		return McpResult{Tool: m.Tool, Output: m.Parameters["n"]}, nil
+	})
+	var buf bytes.Buffer
+	rec := NewRecordingExecutor(live, &buf)
+	calls := []McpExecute{
+		{Tool: "echo", Parameters: map[string]any{"n": 1}},
+		{Tool: "echo", Parameters: map[string]any{"n": 2}},
+		{Tool: "echo", Parameters: map[string]any{"n": 1}},
+		{Tool: "down"},
+	}
+	for _, m := range calls {
+		rec.Execute(ctx, m)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(calls) {
+		t.Fatalf("recorded %d lines, want %d", n, len(calls))
+	}
+
+	replay, err := NewReplayExecutor(bytes.NewReader(buf.Bytes()), ReplayOptions{RequireAllConsumed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := replay.Execute(ctx, McpExecute{Tool: "echo", Parameters: map[string]any{"n": 2.0}})
+	if err != nil || r.Output != float64(2) {
+		t.Errorf("replayed echo(2) = %+v, %v", r, err)
+	}
+	if _, err := replay.Execute(ctx, McpExecute{Tool: "down"}); err == nil || err.Error() != "connection refused" {
+		t.Errorf("replayed error = %v", err)
+	}
+	if _, err := replay.Execute(ctx, McpExecute{Tool: "echo", Parameters: map[string]any{"n": 3}}); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("expected ErrUnexpectedCall, got %v", err)
+	}
+	if got := replay.Unmatched(); len(got) != 1 || got[0].Parameters["n"] != 3 {
+		t.Errorf("Unmatched = %v", got)
+	}
+	if replay.Remaining() != 2 {
+		t.Errorf("Remaining = %d, want 2", replay.Remaining())
+	}
+	err = replay.Verify()
+	if err == nil || !strings.Contains(err.Error(), "unmatched call echo(n=3)") || !strings.Contains(err.Error(), "unconsumed") {
+		t.Errorf("Verify = %v", err)
+	}
+
+	lenient, _ := NewReplayExecutor(bytes.NewReader(buf.Bytes()), ReplayOptions{})
+	for i := 0; i < 2; i++ {
+		if _, err := lenient.Execute(ctx, calls[0]); err != nil {
+			t.Fatalf("repeated recording %d: %v", i, err)
+		}
+	}
+	if err := lenient.Verify(); err != nil {
+		t.Errorf("lenient Verify = %v", err)
+	}
+	if _, err := NewReplayExecutor(strings.NewReader("{not json}\n"), ReplayOptions{}); err == nil {
+		t.Error("expected error for malformed recording")
+	}
+}
+
+func TestReplayErrorKinds(t *testing.T) {
+	ctx := context.Background()
+	merr := &McpError{Code: ToolNotFound, Message: "no such tool", Details: map[string]any{"tool": "ghost"}}
+	live := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		switch m.Tool {
+		case "mcp":
+			return McpResult{}, fmt.Errorf("call ghost: %w", merr)
+		case "slow":
+			return McpResult{}, fmt.Errorf("call slow: %w", context.DeadlineExceeded)
+		case "stopped":
+			return McpResult{}, context.Canceled
+		}
+		return McpResult{}, ErrExecutorClosed
+	})
+	var buf bytes.Buffer
+	rec := NewRecordingExecutor(live, &buf)
+	tools := []string{"mcp", "slow", "stopped", "closed"}
+	originals := make([]error, len(tools))
+	for i, tool := range tools {
+		_, originals[i] = rec.Execute(ctx, McpExecute{Tool: tool})
+	}
+
+	replay, err := NewReplayExecutor(&buf, ReplayOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sentinels := []error{nil, context.DeadlineExceeded, context.Canceled, ErrExecutorClosed}
+	for i, tool := range tools {
+		_, err := replay.Execute(ctx, McpExecute{Tool: tool})
+		if err == nil || err.Error() != originals[i].Error() {
+			t.Errorf("%s: replayed %v, want %v", tool, err, originals[i])
+			continue
+		}
+		if sentinels[i] != nil && !errors.Is(err, sentinels[i]) {
+			t.Errorf("%s: replayed error does not wrap %v", tool, sentinels[i])
+		}
+		if errorCode(err) != errorCode(originals[i]) {
+			t.Errorf("%s: code = %s, want %s", tool, errorCode(err), errorCode(originals[i]))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+)
+
+// RunOptions configures RunBatch.
+type RunOptions struct {
+	// MaxConcurrency bounds the number of executions in flight; zero means
+	// unbounded. It is ignored for sequential batches, which run one at a
+	// time in order.
+	MaxConcurrency int
+}
+
+// RunBatch executes every request in b with ex and returns one result per
+// request, in batch order. A Go error from ex becomes a result carrying an
+// McpError, classified as Timeout or Cancelled for context errors. When ctx
+// is done, executions still running or not yet started are reported as
+// Timeout (or Cancelled, if ctx was cancelled rather than expired) without
+// waiting for them to return.
+func RunBatch(ctx context.Context, ex Executor, b McpBatch, opts RunOptions) McpBatchResult {
+	n := len(b.Executes)
+	limit := opts.MaxConcurrency
+	if b.Sequential {
+		limit = 1
+	}
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	c := NewResultCollector(n)
+	sem := make(chan struct{}, max(limit, 1))
+	go func() {
+		for i, m := range b.Executes {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			// select picks at random when both cases are ready, so a free
+			// slot does not mean ctx is still live.
+			if ctx.Err() != nil {
+				return
+			}
+			go func(i int, m McpExecute) {
+				defer func() { <-sem }()
+				_ = c.Add(i, runOne(ctx, ex, m))
+			}(i, m)
+		}
+	}()
+
+	out, err := c.WaitCtx(ctx)
+	if err == nil {
+		return out
+	}
+	code, msg := Timeout, "batch deadline exceeded before the execution finished"
+	if errors.Is(err, context.Canceled) {
+		code, msg = Cancelled, "batch cancelled before the execution finished"
+	}
+	return c.fillMissing(func(i int) McpResult {
+		return ErrorResult(b.Executes[i].Tool, &McpError{Code: code, Message: msg})
+	})
+}
+
+// runOne executes m, converting a Go error into a failed result.
+func runOne(ctx context.Context, ex Executor, m McpExecute) McpResult {
+	r, err := ex.Execute(ctx, m)
+	if err == nil {
+		return r
+	}
+	var merr *McpError
+	if !errors.As(err, &merr) {
+		merr = &McpError{Code: errorCode(err), Message: err.Error()}
+	}
+	return ErrorResult(m.Tool, merr)
+}
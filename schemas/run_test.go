@@ -0,0 +1,113 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatchConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	ex := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		if m.Tool == "broken" {
+			return McpResult{}, errors.New("connection reset")
+		}
+		return McpResult{Tool: m.Tool}, nil
+	})
+	b := McpBatch{}
+	for i := 0; i < 8; i++ {
+		b.Executes = append(b.Executes, McpExecute{Tool: "t"})
+	}
+	b.Executes[3].Tool = "broken"
+
+	out := RunBatch(context.Background(), ex, b, RunOptions{MaxConcurrency: 3})
+	if len(out.Results) != 8 {
+		t.Fatalf("got %d results", len(out.Results))
+	}
+	if p := peak.Load(); p > 3 || p < 2 {
+		t.Errorf("peak concurrency = %d, want at most 3", p)
+	}
+	serr, failed := out.Results[3].StructuredError()
+	if !failed || serr.Code != Unknown || serr.Message != "connection reset" || out.Results[3].Tool != "broken" {
+		t.Errorf("failed result = %+v", out.Results[3])
+	}
+	if out.Results[0].IsError() || out.Results[7].IsError() {
+		t.Error("successful calls reported as errors")
+	}
+}
+
+func TestRunBatchSequential(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	ex := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		mu.Lock()
+		order = append(order, m.Tool)
+		mu.Unlock()
+		return McpResult{Tool: m.Tool}, nil
+	})
+	b := McpBatch{Sequential: true, Executes: []McpExecute{{Tool: "a"}, {Tool: "b"}, {Tool: "c"}}}
+	RunBatch(context.Background(), ex, b, RunOptions{MaxConcurrency: 10})
+	if len(order) != 3 || order[0] != "a" || order[1] != "b" || order[2] != "c" {
+		t.Errorf("execution order = %v", order)
+	}
+}
+
+func TestRunBatchDeadline(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	ex := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		if m.Tool == "slow" {
+			<-block
+		}
+		return McpResult{Tool: m.Tool}, nil
+	})
+	b := McpBatch{Sequential: true, Executes: []McpExecute{{Tool: "fast"}, {Tool: "slow"}, {Tool: "never"}}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	out := RunBatch(ctx, ex, b, RunOptions{})
+	if out.Results[0].IsError() {
+		t.Errorf("fast call = %+v", out.Results[0])
+	}
+	for _, i := range []int{1, 2} {
+		serr, failed := out.Results[i].StructuredError()
+		if !failed || serr.Code != Timeout || out.Results[i].Tool != b.Executes[i].Tool {
+			t.Errorf("Results[%d] = %+v, want Timeout", i, out.Results[i])
+		}
+	}
+
+	cctx, ccancel := context.WithCancel(context.Background())
+	ccancel()
+	out = RunBatch(cctx, ex, McpBatch{Executes: []McpExecute{{Tool: "slow"}}}, RunOptions{})
+	if serr, _ := out.Results[0].StructuredError(); serr == nil || serr.Code != Cancelled {
+		t.Errorf("cancelled batch = %+v", out.Results[0])
+	}
+	var launched atomic.Int32
+	counting := ExecutorFunc(func(_ context.Context, m McpExecute) (McpResult, error) {
+		launched.Add(1)
+		return McpResult{Tool: m.Tool}, nil
+	})
+	many := McpBatch{Executes: make([]McpExecute, 50)}
+	for i := range many.Executes {
+		many.Executes[i] = McpExecute{Tool: "t"}
+	}
+	RunBatch(cctx, counting, many, RunOptions{})
+	time.Sleep(10 * time.Millisecond)
+	if n := launched.Load(); n != 0 {
+		t.Errorf("cancelled batch launched %d executions", n)
+	}
+	if got := RunBatch(context.Background(), ex, McpBatch{}, RunOptions{}); len(got.Results) != 0 {
+		t.Errorf("empty batch = %+v", got)
+	}
+}
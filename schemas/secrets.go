@@ -0,0 +1,56 @@
+package schemas
+
+import "fmt"
+
+// secretRefKey is the sole key of a decoded secret reference object.
+const secretRefKey = "$secret"
+
+// SecretRef is a parameter value standing in for a secret that is looked up
+// at execution time. It encodes as {"$secret": "NAME"}.
+type SecretRef struct {
+	Name string `json:"$secret" yaml:"$secret" doc:"The name of the secret to substitute."`
+}
+
+// asSecretRef reports whether v is a secret reference, either as a SecretRef
+// or as an object of any map type whose only key is "$secret" with a string
+// value.
+func asSecretRef(v any) (string, bool) {
+	switch x := v.(type) {
+	case SecretRef:
+		return x.Name, true
+	case *SecretRef:
+		if x != nil {
+			return x.Name, true
+		}
+		return "", false
+	}
+	if jsonKind(v) == kindObject {
+		if obj := toObject(v); len(obj) == 1 {
+			return stringValue(obj[secretRefKey])
+		}
+	}
+	return "", false
+}
+
+// ResolveSecrets returns a copy of the request in which every secret
+// reference, at any depth and inside objects and arrays of any Go type, is
+// replaced by the value lookup returns for its name. It fails on the first
+// reference, in key order, that lookup cannot resolve.
+func (m McpExecute) ResolveSecrets(lookup func(name string) (string, bool)) (McpExecute, error) {
+	out := m.Clone()
+	err := rewriteObject("", out.Parameters, func(path, _ string, v any) (any, bool, error) {
+		name, ok := asSecretRef(v)
+		if !ok {
+			return v, false, nil
+		}
+		secret, found := lookup(name)
+		if !found {
+			return nil, true, &ValidationError{Field: "parameters." + path, Message: fmt.Sprintf("unresolved secret %q", name)}
+		}
+		return secret, true, nil
+	})
+	if err != nil {
+		return McpExecute{}, err
+	}
+	return out, nil
+}
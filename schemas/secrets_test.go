@@ -0,0 +1,72 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	secrets := map[string]string{"JULES_API_KEY": "jk-1", "GITHUB_TOKEN": "ghp-2"}
+	lookup := func(name string) (string, bool) {
+		s, ok := secrets[name]
+		return s, ok
+	}
+	m := McpExecute{Tool: "jules_create_session", Parameters: map[string]any{
+		"apiKey":  map[string]any{"$secret": "JULES_API_KEY"},
+		"headers": []any{map[string]any{"token": SecretRef{Name: "GITHUB_TOKEN"}}},
+		"prompt":  "fix the build",
+	}}
+	got, err := m.ResolveSecrets(lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"apiKey":  "jk-1",
+		"headers": []any{map[string]any{"token": "ghp-2"}},
+		"prompt":  "fix the build",
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("got %#v, want %#v", got.Parameters, want)
+	}
+	if _, ok := m.Parameters["apiKey"].(map[string]any); !ok {
+		t.Error("ResolveSecrets mutated the original request")
+	}
+
+	_, err = McpExecute{Tool: "t", Parameters: map[string]any{"k": SecretRef{Name: "MISSING"}}}.ResolveSecrets(lookup)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !strings.Contains(verr.Message, "MISSING") {
+		t.Errorf("expected error naming the secret, got %v", err)
+	}
+}
+
+func TestResolveSecretsTypedContainers(t *testing.T) {
+	lookup := func(name string) (string, bool) { return "v-" + name, name != "NOPE" }
+	m := McpExecute{Tool: "t", Parameters: map[string]any{
+		"backends": []map[string]any{{"auth": map[string]any{"$secret": "A"}}},
+		"ref":      map[string]string{"$secret": "B"},
+	}}
+	got, err := m.ResolveSecrets(lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"backends": []any{map[string]any{"auth": "v-A"}},
+		"ref":      "v-B",
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("got %#v, want %#v", got.Parameters, want)
+	}
+
+	for _, params := range []map[string]any{
+		{"list": []map[string]any{{"k": map[string]any{"$secret": "NOPE"}}}},
+		{"typed": map[string]map[string]string{"k": {"$secret": "NOPE"}}},
+	} {
+		_, err := McpExecute{Tool: "t", Parameters: params}.ResolveSecrets(lookup)
+		var verr *ValidationError
+		if !errors.As(err, &verr) || !strings.Contains(verr.Message, "NOPE") {
+			t.Errorf("%v: expected error naming the secret, got %v", params, err)
+		}
+	}
+}
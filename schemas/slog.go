@@ -0,0 +1,98 @@
+package schemas
+
+import "log/slog"
+
+// DefaultSensitiveKeys lists the parameter keys whose values LogValue,
+// String and Pretty redact, matching case-insensitively at any nesting
+// depth. SpanAttributes omits top-level parameters with these keys.
+var DefaultSensitiveKeys = []string{
+	"apiKey", "api_key", "authorization", "password", "secret", "token", "accessToken", "refreshToken",
+}
+
+// maxLogParams bounds the number of parameters LogValue reports.
+const maxLogParams = 16
+
+// LogValue implements slog.LogValuer. It logs the tool name, the parameter
+// count and a params group summarizing, for at most the first 16 keys in
+// sorted order, each value after redacting DefaultSensitiveKeys. Strings
+// are truncated to 40 characters and objects and arrays are reduced to
+// their size, as in String.
+func (m McpExecute) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("tool", m.Tool),
+		slog.Int("paramCount", len(m.Parameters)),
+	}
+	if len(m.Parameters) == 0 {
+		return slog.GroupValue(attrs...)
+	}
+	redacted := m.Redacted(DefaultSensitiveKeys)
+	keys := sortedKeys(redacted.Parameters)
+	if len(keys) > maxLogParams {
+		keys = keys[:maxLogParams]
+	}
+	params := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, logParam(k, redacted.Parameters[k]))
+	}
+	attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params...)})
+	return slog.GroupValue(attrs...)
+}
+
+func logParam(key string, v any) slog.Attr {
+	v = deref(v)
+	switch jsonKind(v) {
+	case kindNull:
+		return slog.Any(key, nil)
+	case kindBoolean:
+		b, _ := boolValue(v)
+		return slog.Bool(key, b)
+	case kindInteger, kindNumber:
+		if n, ok := asInt64(v); ok {
+			return slog.Int64(key, n)
+		}
+		if f, ok := toFloat(v); ok {
+			return slog.Float64(key, f)
+		}
+	case kindString:
+		str, _ := stringValue(v)
+		return slog.String(key, truncateString(str))
+	}
+	return slog.String(key, formatCompact(v))
+}
+
+// LogValue implements slog.LogValuer, logging the wrapped request as
+// McpExecute.LogValue does together with the envelope metadata that is set.
+func (e McpExecuteEnvelope) LogValue() slog.Value {
+	attrs := e.McpExecute.LogValue().Group()
+	if e.CorrelationID != "" {
+		attrs = append(attrs, slog.String("correlationId", e.CorrelationID))
+	}
+	if e.Deadline != nil {
+		attrs = append(attrs, slog.Time("deadline", *e.Deadline))
+	}
+	if e.IdempotencyKey != "" {
+		attrs = append(attrs, slog.String("idempotencyKey", e.IdempotencyKey))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, logging the wrapped request as
+// McpExecute.LogValue does together with the approval requirement.
+func (g McpExecuteGuarded) LogValue() slog.Value {
+	attrs := append(g.McpExecute.LogValue().Group(), slog.Bool("approvalRequired", g.ApprovalRequired))
+	if g.ApprovalReason != "" {
+		attrs = append(attrs, slog.String("approvalReason", g.ApprovalReason))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, logging the wrapped request as
+// McpExecute.LogValue does together with its policy.
+func (e McpExecuteWithPolicy) LogValue() slog.Value {
+	attrs := append(e.McpExecute.LogValue().Group(), slog.Group("policy",
+		slog.Int64("timeoutMs", e.Policy.TimeoutMs),
+		slog.Int("maxRetries", e.Policy.MaxRetries),
+		slog.Int64("retryBackoffMs", e.Policy.RetryBackoffMs),
+	))
+	return slog.GroupValue(attrs...)
+}
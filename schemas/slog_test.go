@@ -0,0 +1,122 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service":  "api",
+		"replicas": 3,
+		"ratio":    0.5,
+		"dryRun":   true,
+		"Token":    "s3cr3t",
+		"note":     strings.Repeat("x", 100),
+		"config":   map[string]any{"password": "hunter2", "region": "eu"},
+	}}
+	logger.Info("dispatching", "call", m)
+	if strings.Contains(buf.String(), "s3cr3t") || strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("log leaked a secret: %s", buf.String())
+	}
+
+	var rec struct {
+		Call struct {
+			Tool       string         `json:"tool"`
+			ParamCount int            `json:"paramCount"`
+			Params     map[string]any `json:"params"`
+		} `json:"call"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"Token":    "***",
+		"config":   "{…2}",
+		"dryRun":   true,
+		"note":     strings.Repeat("x", 40) + "…",
+		"ratio":    0.5,
+		"replicas": float64(3),
+		"service":  "api",
+	}
+	if rec.Call.Tool != "deploy" || rec.Call.ParamCount != 7 {
+		t.Errorf("call = %+v", rec.Call)
+	}
+	for k, v := range want {
+		if rec.Call.Params[k] != v {
+			t.Errorf("params[%s] = %v, want %v", k, rec.Call.Params[k], v)
+		}
+	}
+	if len(rec.Call.Params) != len(want) {
+		t.Errorf("params = %v", rec.Call.Params)
+	}
+}
+
+func TestLogValueBounded(t *testing.T) {
+	params := make(map[string]any)
+	for i := 0; i < 40; i++ {
+		params[strings.Repeat("k", i+1)] = i
+	}
+	v := McpExecute{Tool: "t", Parameters: params}.LogValue()
+	group := v.Group()
+	if len(group) != 3 || len(group[2].Value.Group()) != maxLogParams {
+		t.Errorf("LogValue = %v", v)
+	}
+}
+
+func TestLogValuePointerParams(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	name, on := "api", true
+	var unset *bool
+	logger.Info("dispatching", "call", McpExecute{Tool: "t", Parameters: map[string]any{
+		"service": &name, "dryRun": &on, "force": unset,
+	}})
+	var rec struct {
+		Call struct {
+			Params map[string]any `json:"params"`
+		} `json:"call"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("%v: %s", err, buf.String())
+	}
+	if p := rec.Call.Params; p["service"] != "api" || p["dryRun"] != true || p["force"] != nil {
+		t.Errorf("params = %v", p)
+	}
+}
+
+func TestWrapperLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	base := McpExecute{Tool: "t", Parameters: map[string]any{"token": "s3cr3t"}}
+	logger.Info("x",
+		"env", McpExecuteEnvelope{McpExecute: base, CorrelationID: "run-1", IdempotencyKey: "k"},
+		"guarded", McpExecuteGuarded{McpExecute: base, ApprovalRequired: true, ApprovalReason: "prod"},
+		"policy", McpExecuteWithPolicy{McpExecute: base, Policy: McpExecutePolicy{MaxRetries: 2}},
+	)
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Fatalf("log leaked a secret: %s", buf.String())
+	}
+	var rec struct {
+		Env     map[string]any `json:"env"`
+		Guarded map[string]any `json:"guarded"`
+		Policy  map[string]any `json:"policy"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Env["tool"] != "t" || rec.Env["correlationId"] != "run-1" || rec.Env["idempotencyKey"] != "k" {
+		t.Errorf("env = %v", rec.Env)
+	}
+	if rec.Guarded["approvalRequired"] != true || rec.Guarded["approvalReason"] != "prod" {
+		t.Errorf("guarded = %v", rec.Guarded)
+	}
+	if p, _ := rec.Policy["policy"].(map[string]any); p["maxRetries"] != float64(2) {
+		t.Errorf("policy = %v", rec.Policy)
+	}
+}
@@ -0,0 +1,83 @@
+package schemas
+
+import "encoding/json"
+
+// ParamStats summarizes the size and shape of a request's parameters.
+type ParamStats struct {
+	Keys     int `json:"keys" doc:"The number of top-level parameters."`
+	MaxDepth int `json:"maxDepth" doc:"The deepest nesting level; top-level parameters have depth 1."`
+	Bytes    int `json:"bytes" doc:"The size of the canonical JSON encoding of the request, or -1 if it cannot be encoded."`
+	Strings  int `json:"strings" doc:"The number of string values at any depth."`
+	Numbers  int `json:"numbers" doc:"The number of numeric values at any depth."`
+	Booleans int `json:"booleans" doc:"The number of boolean values at any depth."`
+	Objects  int `json:"objects" doc:"The number of object values at any depth."`
+	Arrays   int `json:"arrays" doc:"The number of array values at any depth."`
+	Nulls    int `json:"nulls" doc:"The number of null values at any depth."`
+}
+
+// Stats returns size and shape statistics for the parameters. It walks the
+// values in place and measures the encoded size without buffering it, so
+// it is cheap enough to run on every call.
+func (m McpExecute) Stats() ParamStats {
+	s := ParamStats{Keys: len(m.Parameters)}
+	for _, v := range m.Parameters {
+		s.add(v, 1)
+	}
+	var w byteCounter
+	enc := json.NewEncoder(&w)
+	enc.SetEscapeHTML(false)
+	if m.SchemaVersion == CurrentSchemaVersion {
+		m.SchemaVersion = 0
+	}
+	if err := enc.Encode(m); err != nil {
+		s.Bytes = -1
+	} else {
+		// Encode appends a newline that MarshalCanonical trims.
+		s.Bytes = int(w) - 1
+	}
+	return s
+}
+
+func (s *ParamStats) add(v any, depth int) {
+	s.MaxDepth = max(s.MaxDepth, depth)
+	switch jsonKind(v) {
+	case kindNull:
+		s.Nulls++
+	case kindBoolean:
+		s.Booleans++
+	case kindString:
+		s.Strings++
+	case kindInteger, kindNumber:
+		s.Numbers++
+	case kindObject:
+		s.Objects++
+		if obj, ok := v.(map[string]any); ok {
+			for _, item := range obj {
+				s.add(item, depth+1)
+			}
+		} else {
+			for _, item := range toObject(v) {
+				s.add(item, depth+1)
+			}
+		}
+	case kindArray:
+		s.Arrays++
+		if arr, ok := v.([]any); ok {
+			for _, item := range arr {
+				s.add(item, depth+1)
+			}
+		} else if _, isBytes := v.([]byte); !isBytes {
+			for _, item := range toArray(v) {
+				s.add(item, depth+1)
+			}
+		}
+	}
+}
+
+// byteCounter is an io.Writer that counts the bytes written to it.
+type byteCounter int
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
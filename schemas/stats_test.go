@@ -0,0 +1,38 @@
+package schemas
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"service": "api",
+		"ports":   []any{80.0, 443.0},
+		"config":  map[string]any{"debug": false, "parent": nil, "tags": []string{"a", "b"}},
+	}}
+	got := m.Stats()
+	want := ParamStats{
+		Keys: 3, MaxDepth: 3,
+		Strings: 3, Numbers: 2, Booleans: 1, Objects: 1, Arrays: 2, Nulls: 1,
+	}
+	data, _ := m.MarshalCanonical()
+	want.Bytes = len(data)
+	if got != want {
+		t.Errorf("Stats =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := (McpExecute{Tool: "t"}).Stats(); got.Keys != 0 || got.MaxDepth != 0 || got.Bytes != len(`{"tool":"t"}`) {
+		t.Errorf("empty Stats = %+v", got)
+	}
+	if got := (McpExecute{Tool: "t", Parameters: map[string]any{"f": func() {}}}).Stats(); got.Bytes != -1 {
+		t.Errorf("unencodable Stats.Bytes = %d", got.Bytes)
+	}
+}
+
+func BenchmarkStats(b *testing.B) {
+	m := McpExecute{Tool: "search", Parameters: map[string]any{
+		"query": "golang", "limit": 10.0, "filters": map[string]any{"lang": "en", "tags": []any{"a", "b"}},
+	}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Stats()
+	}
+}
@@ -0,0 +1,376 @@
+package schemas
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MCPProtocolVersion is the MCP protocol revision requested during initialization.
+const MCPProtocolVersion = "2025-06-18"
+
+// ErrExecutorClosed is returned by StdioExecutor once it has shut down.
+var ErrExecutorClosed = errors.New("MCP stdio executor closed")
+
+// StdioOptions configures the subprocess started by NewStdioExecutor.
+type StdioOptions struct {
+	// Command and Args name the MCP server to launch.
+	Command string
+	Args    []string
+	// Env and Dir are passed to exec.Cmd; empty values inherit from the
+	// current process.
+	Env []string
+	Dir string
+	// Stderr receives the server's standard error; it is discarded if nil.
+	Stderr io.Writer
+	// ClientName and ClientVersion identify the client during initialization.
+	ClientName    string
+	ClientVersion string
+	// ShutdownTimeout bounds how long Close waits for the server to exit
+	// after its input is closed before killing it; it defaults to five seconds.
+	ShutdownTimeout time.Duration
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int64 `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type rpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// toolCallResult is the result of an MCP tools/call request.
+type toolCallResult struct {
+	Content           []map[string]any `json:"content"`
+	StructuredContent any              `json:"structuredContent,omitempty"`
+	IsError           bool             `json:"isError,omitempty"`
+}
+
+// StdioExecutor is an Executor that runs tools on an MCP server launched as
+// a subprocess, exchanging newline-delimited JSON-RPC 2.0 messages over its
+// standard input and output. A single server process serves all calls; it
+// is safe for concurrent use.
+type StdioExecutor struct {
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	shutdown time.Duration
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan rpcMessage
+	err     error
+
+	done     chan struct{}
+	exited   chan struct{}
+	closeErr error
+	once     sync.Once
+}
+
+// NewStdioExecutor launches the MCP server and performs the initialization
+// handshake. Cancelling ctx shuts the server down.
+func NewStdioExecutor(ctx context.Context, opts StdioOptions) (*StdioExecutor, error) {
+	if opts.Command == "" {
+		return nil, &ValidationError{Field: "command", Message: "must not be empty"}
+	}
+	cmd := exec.Command(opts.Command, opts.Args...)
+	cmd.Env = opts.Env
+	cmd.Dir = opts.Dir
+	cmd.Stderr = opts.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start MCP server %q: %w", opts.Command, err)
+	}
+
+	e := &StdioExecutor{
+		cmd:      cmd,
+		stdin:    stdin,
+		shutdown: opts.ShutdownTimeout,
+		pending:  make(map[int64]chan rpcMessage),
+		done:     make(chan struct{}),
+		exited:   make(chan struct{}),
+	}
+	if e.shutdown <= 0 {
+		e.shutdown = 5 * time.Second
+	}
+	go e.readLoop(stdout)
+	go func() {
+		select {
+		case <-ctx.Done():
+			e.Close()
+		case <-e.done:
+		}
+	}()
+
+	name, version := opts.ClientName, opts.ClientVersion
+	if name == "" {
+		name = "antigravity-jules-orchestration"
+	}
+	if version == "" {
+		version = "0.0.0"
+	}
+	initParams := map[string]any{
+		"protocolVersion": MCPProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": name, "version": version},
+	}
+	if _, err := e.call(ctx, "initialize", initParams); err != nil {
+		e.Close()
+		return nil, fmt.Errorf("initialize MCP server %q: %w", opts.Command, err)
+	}
+	if err := e.send(rpcRequest{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		e.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// Execute sends m as a tools/call request and waits for the response. If ctx
+// is cancelled first, the server is notified and ctx.Err() is returned.
+func (e *StdioExecutor) Execute(ctx context.Context, m McpExecute) (McpResult, error) {
+	start := time.Now()
+	args := m.Parameters
+	if args == nil {
+		args = map[string]any{}
+	}
+	resp, err := e.call(ctx, "tools/call", map[string]any{"name": m.Tool, "arguments": args})
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		return McpResult{}, err
+	}
+	if resp.Error != nil {
+		var data any
+		if len(resp.Error.Data) > 0 && json.Unmarshal(resp.Error.Data, &data) != nil {
+			data = string(resp.Error.Data)
+		}
+		merr := McpErrorFromJSONRPC(resp.Error.Code, resp.Error.Message, data)
+		r := ErrorResult(m.Tool, &merr)
+		r.DurationMs = elapsed
+		return r, nil
+	}
+
+	var res toolCallResult
+	if err := json.Unmarshal(resp.Result, &res); err != nil {
+		return McpResult{}, fmt.Errorf("tool %q: invalid tools/call result: %w", m.Tool, err)
+	}
+	r := McpResult{Tool: m.Tool, DurationMs: elapsed, Output: res.StructuredContent}
+	if r.Output == nil {
+		r.Output = contentOutput(res.Content)
+	}
+	if res.IsError {
+		r = ErrorResult(m.Tool, &McpError{Code: ToolInternal, Message: contentText(res.Content), Details: map[string]any{"content": res.Content}})
+		r.DurationMs = elapsed
+		r.ExitCode = 1
+	}
+	return r, nil
+}
+
+// Close shuts the server down by closing its input, killing it if it has not
+// exited within the shutdown timeout, and fails any calls still waiting.
+func (e *StdioExecutor) Close() error {
+	e.once.Do(func() {
+		e.fail(ErrExecutorClosed)
+		if err := e.stdin.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			e.closeErr = err
+		}
+		select {
+		case <-e.exited:
+		case <-time.After(e.shutdown):
+			_ = e.cmd.Process.Kill()
+			<-e.exited
+		}
+	})
+	return e.closeErr
+}
+
+func (e *StdioExecutor) call(ctx context.Context, method string, params any) (rpcMessage, error) {
+	e.mu.Lock()
+	if e.err != nil {
+		err := e.err
+		e.mu.Unlock()
+		return rpcMessage{}, err
+	}
+	e.nextID++
+	id := e.nextID
+	ch := make(chan rpcMessage, 1)
+	e.pending[id] = ch
+	e.mu.Unlock()
+
+	if err := e.send(rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		e.forget(id)
+		return rpcMessage{}, err
+	}
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return rpcMessage{}, e.failure()
+		}
+		return resp, nil
+	case <-ctx.Done():
+		e.forget(id)
+		_ = e.send(rpcRequest{JSONRPC: "2.0", Method: "notifications/cancelled", Params: map[string]any{
+			"requestId": id,
+			"reason":    ctx.Err().Error(),
+		}})
+		return rpcMessage{}, ctx.Err()
+	}
+}
+
+func (e *StdioExecutor) send(msg any) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+	if _, err := e.stdin.Write(append(data, '\n')); err != nil {
+		if ferr := e.failure(); ferr != nil {
+			return ferr
+		}
+		return fmt.Errorf("write to MCP server: %w", err)
+	}
+	return nil
+}
+
+func (e *StdioExecutor) forget(id int64) {
+	e.mu.Lock()
+	delete(e.pending, id)
+	e.mu.Unlock()
+}
+
+func (e *StdioExecutor) failure() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+// fail records err as the terminal error and releases all waiting calls.
+func (e *StdioExecutor) fail(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return
+	}
+	e.err = err
+	for id, ch := range e.pending {
+		close(ch)
+		delete(e.pending, id)
+	}
+	close(e.done)
+}
+
+func (e *StdioExecutor) readLoop(stdout io.Reader) {
+	defer close(e.exited)
+	r := bufio.NewReader(stdout)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			e.dispatch(line)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = fmt.Errorf("MCP server exited: %w", ErrExecutorClosed)
+			}
+			e.fail(err)
+			_ = e.cmd.Wait()
+			return
+		}
+	}
+}
+
+// dispatch routes a message from the server: responses go to the waiting
+// call, pings are answered and other requests are rejected.
+func (e *StdioExecutor) dispatch(line []byte) {
+	var msg rpcMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return
+	}
+	if msg.Method != "" {
+		if len(msg.ID) == 0 {
+			return // notification
+		}
+		resp := map[string]any{"jsonrpc": "2.0", "id": msg.ID}
+		if msg.Method == "ping" {
+			resp["result"] = map[string]any{}
+		} else {
+			resp["error"] = rpcError{Code: -32601, Message: "method not found: " + msg.Method}
+		}
+		// Reply off the read loop: a blocked write must not stop responses
+		// being read, or a server waiting on them would deadlock with us.
+		go func() { _ = e.send(resp) }()
+		return
+	}
+	id, err := strconv.ParseInt(string(msg.ID), 10, 64)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	ch, ok := e.pending[id]
+	delete(e.pending, id)
+	e.mu.Unlock()
+	if ok {
+		ch <- msg
+	}
+}
+
+// contentOutput returns the text of a single text content block, or the
+// content blocks themselves.
+func contentOutput(content []map[string]any) any {
+	if len(content) == 1 && content[0]["type"] == "text" {
+		if text, ok := content[0]["text"].(string); ok {
+			return text
+		}
+	}
+	if content == nil {
+		return nil
+	}
+	out := make([]any, len(content))
+	for i, c := range content {
+		out[i] = c
+	}
+	return out
+}
+
+// contentText joins the text of all text content blocks.
+func contentText(content []map[string]any) string {
+	var parts []string
+	for _, c := range content {
+		if text, ok := c["text"].(string); ok && c["type"] == "text" {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) == 0 {
+		return "tool reported an error"
+	}
+	return strings.Join(parts, "\n")
+}
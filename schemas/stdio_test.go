@@ -0,0 +1,132 @@
+package schemas
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestStdioHelperServer is not a real test: when MCP_STDIO_HELPER is set it
+// turns the test binary into a minimal MCP server for the tests below.
+func TestStdioHelperServer(t *testing.T) {
+	if os.Getenv("MCP_STDIO_HELPER") != "1" {
+		t.Skip("helper process")
+	}
+	out := json.NewEncoder(os.Stdout)
+	in := bufio.NewScanner(os.Stdin)
+	for in.Scan() {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params struct {
+				Name      string         `json:"name"`
+				Arguments map[string]any `json:"arguments"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(in.Bytes(), &req) != nil || req.ID == nil {
+			continue
+		}
+		reply := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		switch {
+		case req.Method == "initialize":
+			reply["result"] = map[string]any{"protocolVersion": MCPProtocolVersion, "capabilities": map[string]any{"tools": map[string]any{}}}
+		case req.Method == "ping":
+			continue
+		case req.Params.Name == "echo":
+			out.Encode(map[string]any{"jsonrpc": "2.0", "id": "srv-1", "method": "ping"})
+			reply["result"] = map[string]any{
+				"content":           []any{map[string]any{"type": "text", "text": "echoed"}},
+				"structuredContent": req.Params.Arguments,
+			}
+		case req.Params.Name == "text":
+			reply["result"] = map[string]any{"content": []any{map[string]any{"type": "text", "text": "hello"}}}
+		case req.Params.Name == "fail":
+			reply["result"] = map[string]any{"isError": true, "content": []any{map[string]any{"type": "text", "text": "boom"}}}
+		case req.Params.Name == "slow":
+			continue
+		default:
+			reply["error"] = map[string]any{"code": -32602, "message": "unknown tool " + req.Params.Name}
+		}
+		out.Encode(reply)
+	}
+	os.Exit(0)
+}
+
+func startHelperServer(t *testing.T, ctx context.Context) *StdioExecutor {
+	t.Helper()
+	e, err := NewStdioExecutor(ctx, StdioOptions{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=^TestStdioHelperServer$"},
+		Env:     append(os.Environ(), "MCP_STDIO_HELPER=1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { e.Close() })
+	return e
+}
+
+func TestStdioExecutor(t *testing.T) {
+	ctx := context.Background()
+	e := startHelperServer(t, ctx)
+
+	r, err := e.Execute(ctx, McpExecute{Tool: "echo", Parameters: map[string]any{"q": "go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.IsError() || !reflect.DeepEqual(r.Output, map[string]any{"q": "go"}) {
+		t.Errorf("echo result = %+v", r)
+	}
+
+	if r, err := e.Execute(ctx, McpExecute{Tool: "text"}); err != nil || r.Output != "hello" {
+		t.Errorf("text result = %+v, %v", r, err)
+	}
+
+	r, err = e.Execute(ctx, McpExecute{Tool: "fail"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serr, ok := r.StructuredError(); !ok || serr.Code != ToolInternal || serr.Message != "boom" {
+		t.Errorf("fail result = %+v", r)
+	}
+
+	r, err = e.Execute(ctx, McpExecute{Tool: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serr, ok := r.StructuredError(); !ok || serr.Code != InvalidParams || serr.Details["jsonrpcCode"] != -32602 {
+		t.Errorf("JSON-RPC error result = %+v", r)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := e.Execute(cctx, McpExecute{Tool: "slow"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow call error = %v", err)
+	}
+
+	if err := e.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if _, err := e.Execute(ctx, McpExecute{Tool: "echo"}); !errors.Is(err, ErrExecutorClosed) {
+		t.Errorf("call after Close error = %v", err)
+	}
+}
+
+func TestStdioExecutorContextShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := startHelperServer(t, ctx)
+	cancel()
+	select {
+	case <-e.exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server not shut down after context cancellation")
+	}
+	if _, err := e.Execute(context.Background(), McpExecute{Tool: "echo"}); !errors.Is(err, ErrExecutorClosed) {
+		t.Errorf("call after shutdown error = %v", err)
+	}
+}
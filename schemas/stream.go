@@ -0,0 +1,67 @@
+package schemas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// McpStreamChunk defines a single increment of output from a streaming MCP tool.
+type McpStreamChunk struct {
+	Tool  string `json:"tool" doc:"The name of the tool emitting the chunk."`
+	Seq   int    `json:"seq" doc:"The zero-based position of the chunk in the stream."`
+	Data  any    `json:"data,omitempty" doc:"Arbitrary output carried by the chunk."`
+	Final bool   `json:"final,omitempty" doc:"Whether this is the last chunk of the stream."`
+}
+
+// AssembleStream combines the chunks of a stream into a single McpResult.
+// Chunks may be given in any order, but their sequence numbers must be
+// contiguous from zero and only the last one may be final. When every chunk
+// carries string data the output is their concatenation; otherwise it is
+// the slice of chunk data in sequence order.
+func AssembleStream(chunks []McpStreamChunk) (McpResult, error) {
+	if len(chunks) == 0 {
+		return McpResult{}, &ValidationError{Field: "chunks", Message: "must not be empty"}
+	}
+	ordered := make([]McpStreamChunk, len(chunks))
+	copy(ordered, chunks)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Seq < ordered[j].Seq })
+
+	tool := ordered[0].Tool
+	allStrings := true
+	for i, c := range ordered {
+		if c.Seq != i {
+			return McpResult{}, &ValidationError{Field: "seq", Message: fmt.Sprintf("expected %d, got %d", i, c.Seq)}
+		}
+		if c.Tool != tool {
+			return McpResult{}, &ValidationError{Field: "tool", Message: fmt.Sprintf("chunk %d is from %q, expected %q", i, c.Tool, tool)}
+		}
+		if c.Final && i != len(ordered)-1 {
+			return McpResult{}, &ValidationError{Field: "final", Message: fmt.Sprintf("chunk %d is final but is not the last chunk", i)}
+		}
+		if _, ok := c.Data.(string); !ok && c.Data != nil {
+			allStrings = false
+		}
+	}
+	if !ordered[len(ordered)-1].Final {
+		return McpResult{}, &ValidationError{Field: "final", Message: "stream has no final chunk"}
+	}
+
+	var output any
+	if allStrings {
+		var b strings.Builder
+		for _, c := range ordered {
+			if s, ok := c.Data.(string); ok {
+				b.WriteString(s)
+			}
+		}
+		output = b.String()
+	} else {
+		data := make([]any, len(ordered))
+		for i, c := range ordered {
+			data[i] = c.Data
+		}
+		output = data
+	}
+	return McpResult{Tool: tool, Output: output}, nil
+}
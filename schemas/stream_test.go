@@ -0,0 +1,48 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssembleStream(t *testing.T) {
+	got, err := AssembleStream([]McpStreamChunk{
+		{Tool: "logs", Seq: 1, Data: "world"},
+		{Tool: "logs", Seq: 0, Data: "hello "},
+		{Tool: "logs", Seq: 2, Final: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Tool != "logs" || got.Output != "hello world" {
+		t.Errorf("got %+v", got)
+	}
+
+	got, err = AssembleStream([]McpStreamChunk{
+		{Tool: "scan", Seq: 0, Data: float64(1)},
+		{Tool: "scan", Seq: 1, Data: "two", Final: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Output, []any{float64(1), "two"}) {
+		t.Errorf("output = %#v", got.Output)
+	}
+}
+
+func TestAssembleStreamInvalid(t *testing.T) {
+	tests := map[string][]McpStreamChunk{
+		"empty":        nil,
+		"gap":          {{Seq: 0}, {Seq: 2, Final: true}},
+		"not from 0":   {{Seq: 1, Final: true}},
+		"no final":     {{Seq: 0}, {Seq: 1}},
+		"early final":  {{Seq: 0, Final: true}, {Seq: 1, Final: true}},
+		"mixed tools":  {{Tool: "a", Seq: 0}, {Tool: "b", Seq: 1, Final: true}},
+		"duplicate 0s": {{Seq: 0}, {Seq: 0, Final: true}},
+	}
+	for name, chunks := range tests {
+		if _, err := AssembleStream(chunks); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package schemas
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches ${name} placeholders in string parameters.
+var placeholderPattern = regexp.MustCompile(`\$\{([^{}]+)\}`)
+
+// ResolveOptions controls placeholder substitution.
+type ResolveOptions struct {
+	// AllowMissing leaves unresolved placeholders in place instead of failing.
+	AllowMissing bool
+}
+
+// Resolve returns a copy of the request with ${...} placeholders in the
+// parameters substituted from vars. It fails on the first placeholder that
+// cannot be resolved.
+func (m McpExecute) Resolve(vars map[string]any) (McpExecute, error) {
+	return m.ResolveWithOptions(vars, ResolveOptions{})
+}
+
+// ResolveWithOptions is like Resolve but honours opts.
+//
+// A placeholder name is first looked up as a literal key in vars, then as a
+// dotted path through nested maps, so "${step1.output.file}" matches either
+// vars["step1.output.file"] or vars["step1"]["output"]["file"]. A string
+// consisting of a single placeholder is replaced by the variable's value
+// with its type preserved; placeholders embedded in longer strings are
+// formatted into the string.
+func (m McpExecute) ResolveWithOptions(vars map[string]any, opts ResolveOptions) (McpExecute, error) {
+	r := resolver{vars: vars, opts: opts}
+	out := m.Clone()
+	if err := r.object("", out.Parameters); err != nil {
+		return McpExecute{}, err
+	}
+	return out, nil
+}
+
+type resolver struct {
+	vars map[string]any
+	opts ResolveOptions
+}
+
+// object substitutes placeholders in obj in place. Strings inside objects
+// and arrays of any Go type are visited; typed containers are copied to
+// map[string]any and []any.
+func (r resolver) object(path string, obj map[string]any) error {
+	return rewriteObject(path, obj, func(path, _ string, v any) (any, bool, error) {
+		if s, ok := stringValue(v); ok {
+			resolved, err := r.str(path, s)
+			return resolved, true, err
+		}
+		return v, false, nil
+	})
+}
+
+func (r resolver) str(path, s string) (any, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	if loc := placeholderPattern.FindStringSubmatchIndex(s); loc != nil && loc[0] == 0 && loc[1] == len(s) {
+		name := s[loc[2]:loc[3]]
+		if val, ok := r.lookup(name); ok {
+			return val, nil
+		}
+		if r.opts.AllowMissing {
+			return s, nil
+		}
+		return nil, unresolvedPlaceholder(path, name)
+	}
+	var err error
+	out := placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := match[2 : len(match)-1]
+		if val, ok := r.lookup(name); ok {
+			return fmt.Sprint(val)
+		}
+		if !r.opts.AllowMissing && err == nil {
+			err = unresolvedPlaceholder(path, name)
+		}
+		return match
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r resolver) lookup(name string) (any, bool) {
+	name = strings.TrimSpace(name)
+	if v, ok := r.vars[name]; ok {
+		return v, true
+	}
+	var cur any = r.vars
+	for _, part := range strings.Split(name, ".") {
+		if jsonKind(cur) != kindObject {
+			return nil, false
+		}
+		next, ok := toObject(cur)[part]
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
+
+func unresolvedPlaceholder(path, name string) error {
+	return &ValidationError{Field: "parameters." + path, Message: fmt.Sprintf("unresolved placeholder ${%s}", name)}
+}
@@ -0,0 +1,89 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	vars := map[string]any{
+		"step1": map[string]any{"output": map[string]any{"file": "out.txt", "lines": float64(3)}},
+		"env":   "prod",
+	}
+	m := McpExecute{
+		Tool: "read_file",
+		Parameters: map[string]any{
+			"path":  "${step1.output.file}",
+			"count": "${step1.output.lines}",
+			"label": "deploy-${env}",
+			"list":  []any{"${env}", map[string]any{"inner": "${env}"}},
+		},
+	}
+	got, err := m.Resolve(vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"path":  "out.txt",
+		"count": float64(3),
+		"label": "deploy-prod",
+		"list":  []any{"prod", map[string]any{"inner": "prod"}},
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("got %#v, want %#v", got.Parameters, want)
+	}
+	if m.Parameters["path"] != "${step1.output.file}" {
+		t.Error("Resolve mutated the original request")
+	}
+}
+
+func TestResolveMissing(t *testing.T) {
+	m := McpExecute{Tool: "t", Parameters: map[string]any{"a": []any{"x-${nope}"}}}
+	_, err := m.Resolve(nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Field != "parameters.a[0]" {
+		t.Fatalf("expected unresolved placeholder error, got %v", err)
+	}
+	got, err := m.ResolveWithOptions(nil, ResolveOptions{AllowMissing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Parameters, m.Parameters) {
+		t.Errorf("got %#v, want placeholders left in place", got.Parameters)
+	}
+}
+
+func TestResolveTypedContainers(t *testing.T) {
+	vars := map[string]any{"env": "prod", "replicas": float64(3)}
+	m := McpExecute{Tool: "deploy", Parameters: map[string]any{
+		"labels":  map[string]string{"env": "${env}"},
+		"targets": []map[string]any{{"count": "${replicas}"}},
+		"args":    []string{"--env=${env}"},
+	}}
+	got, err := m.Resolve(vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"labels":  map[string]any{"env": "prod"},
+		"targets": []any{map[string]any{"count": float64(3)}},
+		"args":    []any{"--env=prod"},
+	}
+	if !reflect.DeepEqual(got.Parameters, want) {
+		t.Errorf("got %#v, want %#v", got.Parameters, want)
+	}
+	if m.Parameters["labels"].(map[string]string)["env"] != "${env}" {
+		t.Error("Resolve mutated the original request")
+	}
+
+	for _, params := range []map[string]any{
+		{"list": []map[string]any{{"k": "${missing}"}}},
+		{"typed": map[string]string{"k": "${missing}"}},
+	} {
+		var verr *ValidationError
+		if _, err := (McpExecute{Tool: "t", Parameters: params}).Resolve(vars); !errors.As(err, &verr) {
+			t.Errorf("%v: expected unresolved placeholder error, got %v", params, err)
+		}
+	}
+}
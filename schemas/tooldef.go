@@ -0,0 +1,221 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ToolDefinition describes an MCP tool and the JSON Schema of its parameters.
+type ToolDefinition struct {
+	Name        string          `json:"name" doc:"The name of the tool."`
+	Description string          `json:"description,omitempty" doc:"A human-readable description of the tool."`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty" doc:"The JSON Schema the tool parameters must conform to."`
+	SideEffect  SideEffect      `json:"sideEffect,omitempty" doc:"What the tool changes when it runs; absent means undeclared."`
+}
+
+// SideEffect classifies what running a tool may change.
+type SideEffect string
+
+// Side-effect levels, from least to most risky. The zero value means the
+// tool did not declare one.
+const (
+	ReadOnly    SideEffect = "readOnly"
+	Mutating    SideEffect = "mutating"
+	Destructive SideEffect = "destructive"
+)
+
+// Valid reports whether s is empty or one of the declared levels.
+func (s SideEffect) Valid() bool {
+	switch s {
+	case "", ReadOnly, Mutating, Destructive:
+		return true
+	}
+	return false
+}
+
+// jsonSchema is the subset of JSON Schema understood by ValidateAgainst.
+type jsonSchema struct {
+	Type                 json.RawMessage        `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+}
+
+// parseSchema decodes the definition's input schema. An absent schema
+// accepts any object.
+func (d ToolDefinition) parseSchema() (*jsonSchema, error) {
+	s := &jsonSchema{}
+	if len(bytes.TrimSpace(d.InputSchema)) == 0 {
+		return s, nil
+	}
+	if err := json.Unmarshal(d.InputSchema, s); err != nil {
+		return nil, fmt.Errorf("tool %q: invalid input schema: %w", d.Name, err)
+	}
+	return s, nil
+}
+
+// types returns the type names permitted by the schema, if any.
+func (s *jsonSchema) types() []string {
+	if len(s.Type) == 0 {
+		return nil
+	}
+	var one string
+	if err := json.Unmarshal(s.Type, &one); err == nil {
+		return []string{one}
+	}
+	var many []string
+	_ = json.Unmarshal(s.Type, &many)
+	return many
+}
+
+// closed reports whether the schema sets additionalProperties to false.
+func (s *jsonSchema) closed() bool {
+	return string(bytes.TrimSpace(s.AdditionalProperties)) == "false"
+}
+
+// FieldError describes a single parameter that violates a tool's input schema.
+type FieldError struct {
+	Path    string `json:"path" doc:"The location of the parameter, such as items[0].name."`
+	Message string `json:"message" doc:"A description of the violation."`
+	Rule    string `json:"rule" doc:"The schema keyword that was violated, such as required, type or enum."`
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// FieldErrors is the list of every schema violation found by ValidateAgainst.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	switch len(e) {
+	case 0:
+		return "no schema violations"
+	case 1:
+		return "invalid parameters: " + e[0].Error()
+	}
+	return fmt.Sprintf("invalid parameters: %s (and %d more)", e[0].Error(), len(e)-1)
+}
+
+// schemaValidator collects violations in a deterministic order.
+type schemaValidator struct {
+	violations FieldErrors
+}
+
+func (v *schemaValidator) fail(path, rule, format string, args ...any) {
+	v.violations = append(v.violations, FieldError{Path: path, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) check(s *jsonSchema, path string, value any) {
+	if s == nil {
+		return
+	}
+	kind := jsonKind(value)
+	if types := s.types(); len(types) > 0 && !kindMatches(kind, types) {
+		v.fail(path, "type", "expected %s, got %s", joinTypes(types), kind)
+		return
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		v.fail(path, "enum", "value is not one of the allowed values")
+	}
+	switch kind {
+	case kindObject:
+		v.checkObject(s, path, toObject(value))
+	case kindArray:
+		if s.Items != nil {
+			for i, item := range toArray(value) {
+				v.check(s.Items, path+"["+strconv.Itoa(i)+"]", item)
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) checkObject(s *jsonSchema, path string, obj map[string]any) {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(joinPath(path, name), "required", "missing required property")
+		}
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		prop, known := s.Properties[k]
+		if !known {
+			if s.closed() {
+				v.fail(joinPath(path, k), "additionalProperties", "unknown property")
+			}
+			continue
+		}
+		v.check(prop, joinPath(path, k), obj[k])
+	}
+}
+
+// ValidateAgainst checks that the parameters conform to the tool's input
+// schema. Every violation is collected and returned as FieldErrors, with
+// paths relative to the parameters object; a tool name that does not match
+// the definition is reported as a *ValidationError.
+func (m McpExecute) ValidateAgainst(def ToolDefinition) error {
+	if def.Name != "" && m.Tool != def.Name {
+		return &ValidationError{Field: "tool", Message: fmt.Sprintf("expected %q, got %q", def.Name, m.Tool)}
+	}
+	s, err := def.parseSchema()
+	if err != nil {
+		return err
+	}
+	var v schemaValidator
+	params := m.Parameters
+	if params == nil {
+		params = map[string]any{}
+	}
+	v.checkObject(s, "", params)
+	if len(v.violations) > 0 {
+		return v.violations
+	}
+	return nil
+}
+
+func kindMatches(kind string, types []string) bool {
+	for _, t := range types {
+		if t == kind || (t == kindNumber && kind == kindInteger) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinTypes(types []string) string {
+	if len(types) == 1 {
+		return types[0]
+	}
+	return fmt.Sprintf("one of %v", types)
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, e := range enum {
+		if reflect.DeepEqual(e, value) {
+			return true
+		}
+		if ef, ok := e.(float64); ok {
+			if vf, ok := toFloat(value); ok && ef == vf {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,86 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var searchTool = ToolDefinition{
+	Name: "search",
+	InputSchema: []byte(`{
+		"type": "object",
+		"properties": {
+			"query": {"type": "string"},
+			"limit": {"type": "integer"},
+			"mode":  {"type": "string", "enum": ["fast", "deep"]},
+			"tags":  {"type": "array", "items": {"type": "string"}},
+			"items": {"type": "array", "items": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}},
+				"required": ["name"]
+			}}
+		},
+		"required": ["query"],
+		"additionalProperties": false
+	}`),
+}
+
+func TestValidateAgainst(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   FieldErrors
+	}{
+		{"valid", map[string]any{"query": "go", "limit": float64(10), "tags": []any{"a"}}, nil},
+		{"native int", map[string]any{"query": "go", "limit": 3}, nil},
+		{"missing required", map[string]any{"limit": float64(1)}, FieldErrors{{Path: "query", Rule: "required"}}},
+		{"type mismatch", map[string]any{"query": 42}, FieldErrors{{Path: "query", Rule: "type"}}},
+		{"fractional integer", map[string]any{"query": "go", "limit": 1.5}, FieldErrors{{Path: "limit", Rule: "type"}}},
+		{"enum", map[string]any{"query": "go", "mode": "slow"}, FieldErrors{{Path: "mode", Rule: "enum"}}},
+		{"item type", map[string]any{"query": "go", "tags": []any{"a", 1}}, FieldErrors{{Path: "tags[1]", Rule: "type"}}},
+		{"unknown property", map[string]any{"query": "go", "extra": true}, FieldErrors{{Path: "extra", Rule: "additionalProperties"}}},
+		{"all violations", map[string]any{
+			"limit": "ten",
+			"items": []any{map[string]any{"name": "a"}, map[string]any{}, map[string]any{"name": 1}},
+		}, FieldErrors{
+			{Path: "query", Rule: "required"},
+			{Path: "items[1].name", Rule: "required"},
+			{Path: "items[2].name", Rule: "type"},
+			{Path: "limit", Rule: "type"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := McpExecute{Tool: "search", Parameters: tt.params}.ValidateAgainst(searchTool)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var ferrs FieldErrors
+			if !errors.As(err, &ferrs) {
+				t.Fatalf("expected FieldErrors, got %v", err)
+			}
+			got := make(FieldErrors, len(ferrs))
+			for i, fe := range ferrs {
+				if fe.Message == "" {
+					t.Errorf("%s: empty message", fe.Path)
+				}
+				got[i] = FieldError{Path: fe.Path, Rule: fe.Rule}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAgainstToolMismatch(t *testing.T) {
+	err := McpExecute{Tool: "other", Parameters: map[string]any{"query": "go"}}.ValidateAgainst(searchTool)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Field != "tool" {
+		t.Fatalf("expected tool validation error, got %v", err)
+	}
+}
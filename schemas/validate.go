@@ -0,0 +1,37 @@
+package schemas
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// ValidationError reports a schema field that failed validation.
+type ValidationError struct {
+	Field   string `json:"field" doc:"The name of the offending field."`
+	Message string `json:"message" doc:"A description of the failure."`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// Validate checks that the execution request is well-formed.
+func (m McpExecute) Validate() error {
+	if m.Tool == "" {
+		return &ValidationError{Field: "tool", Message: "must not be empty"}
+	}
+	for _, r := range m.Tool {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return &ValidationError{Field: "tool", Message: fmt.Sprintf("contains invalid character %q", r)}
+		}
+	}
+	if v := m.SchemaVersion; v < 0 || v > CurrentSchemaVersion {
+		return &ValidationError{Field: "schemaVersion", Message: fmt.Sprintf("unsupported version %d", v)}
+	}
+	for key := range m.Parameters {
+		if key == "" {
+			return &ValidationError{Field: "parameters", Message: "keys must not be empty"}
+		}
+	}
+	return nil
+}
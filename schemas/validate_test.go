@@ -0,0 +1,38 @@
+package schemas
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMcpExecuteValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    McpExecute
+		field string
+	}{
+		{"valid", McpExecute{Tool: "jules_create_session", Parameters: map[string]any{"prompt": "x"}}, ""},
+		{"empty tool", McpExecute{}, "tool"},
+		{"whitespace in tool", McpExecute{Tool: "bad tool"}, "tool"},
+		{"control char in tool", McpExecute{Tool: "bad\x00tool"}, "tool"},
+		{"empty parameter key", McpExecute{Tool: "ok", Parameters: map[string]any{"": 1}}, "parameters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.in.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *ValidationError, got %v", err)
+			}
+			if verr.Field != tt.field {
+				t.Errorf("field = %q, want %q", verr.Field, tt.field)
+			}
+		})
+	}
+}
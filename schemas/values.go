@@ -0,0 +1,373 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// JSON value kinds as named by JSON Schema.
+const (
+	kindNull    = "null"
+	kindBoolean = "boolean"
+	kindInteger = "integer"
+	kindNumber  = "number"
+	kindString  = "string"
+	kindArray   = "array"
+	kindObject  = "object"
+)
+
+// jsonKind returns the JSON Schema type name of a parameter value. Whole
+// numbers report "integer"; callers that only care about numbers should
+// treat it as a "number".
+func jsonKind(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return kindNull
+	case bool:
+		return kindBoolean
+	case string:
+		return kindString
+	case float64:
+		if x == math.Trunc(x) && !math.IsInf(x, 0) {
+			return kindInteger
+		}
+		return kindNumber
+	case json.Number:
+		if _, err := x.Int64(); err == nil {
+			return kindInteger
+		}
+		return kindNumber
+	case map[string]any:
+		return kindObject
+	case []any:
+		return kindArray
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return kindInteger
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return kindInteger
+		}
+		return kindNumber
+	case reflect.Slice, reflect.Array:
+		return kindArray
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return kindObject
+		}
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return kindNull
+		}
+		return jsonKind(rv.Elem().Interface())
+	}
+	return ""
+}
+
+// toObject returns v as a string-keyed map. It must only be called for
+// values whose jsonKind is "object".
+func toObject(v any) map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	out := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		out[iter.Key().String()] = iter.Value().Interface()
+	}
+	return out
+}
+
+// toArray returns v as a slice of values. It must only be called for
+// values whose jsonKind is "array".
+func toArray(v any) []any {
+	if s, ok := v.([]any); ok {
+		return s
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	out := make([]any, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
+
+// toFloat converts any numeric value, or a pointer to one, to float64.
+func toFloat(v any) (float64, bool) {
+	switch x := deref(v).(type) {
+	case float64:
+		return x, true
+	case json.Number:
+		f, err := x.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(deref(v))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// asInt64 converts a whole number of any numeric type, or a pointer to one,
+// that fits in an int64.
+func asInt64(v any) (int64, bool) {
+	switch x := deref(v).(type) {
+	case float64:
+		if x == math.Trunc(x) && x >= math.MinInt64 && x < math.MaxInt64 {
+			return int64(x), true
+		}
+		return 0, false
+	case json.Number:
+		n, err := x.Int64()
+		return n, err == nil
+	}
+	rv := reflect.ValueOf(deref(v))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() <= math.MaxInt64 {
+			return int64(rv.Uint()), true
+		}
+	case reflect.Float32:
+		if f := rv.Float(); f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+// valuesEqual compares two parameter values structurally, treating numbers,
+// strings and booleans of different Go types, or behind pointers, as equal
+// when their values are equal.
+func valuesEqual(a, b any) bool {
+	ka, kb := jsonKind(a), jsonKind(b)
+	isNum := func(k string) bool { return k == kindInteger || k == kindNumber }
+	switch {
+	case isNum(ka) && isNum(kb):
+		if ia, ok := asInt64(a); ok {
+			if ib, ok := asInt64(b); ok {
+				return ia == ib
+			}
+		}
+		fa, okA := toFloat(a)
+		fb, okB := toFloat(b)
+		return okA && okB && fa == fb
+	case ka != kb:
+		return false
+	case ka == kindObject:
+		oa, ob := toObject(a), toObject(b)
+		if len(oa) != len(ob) {
+			return false
+		}
+		for k, va := range oa {
+			vb, ok := ob[k]
+			if !ok || !valuesEqual(va, vb) {
+				return false
+			}
+		}
+		return true
+	case ka == kindArray:
+		sa, sb := toArray(a), toArray(b)
+		if len(sa) != len(sb) {
+			return false
+		}
+		for i := range sa {
+			if !valuesEqual(sa[i], sb[i]) {
+				return false
+			}
+		}
+		return true
+	case ka == kindString:
+		sa, _ := stringValue(a)
+		sb, _ := stringValue(b)
+		return sa == sb
+	case ka == kindBoolean:
+		ba, _ := boolValue(a)
+		bb, _ := boolValue(b)
+		return ba == bb
+	case ka == kindNull:
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// deref follows pointers and interfaces to the value they refer to. A nil
+// pointer yields nil.
+func deref(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer && rv.Kind() != reflect.Interface {
+		return v
+	}
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+// stringValue returns the string held by v, which may be of a named string
+// type or behind a pointer.
+func stringValue(v any) (string, bool) {
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+	if rv := reflect.ValueOf(deref(v)); rv.Kind() == reflect.String {
+		return rv.String(), true
+	}
+	return "", false
+}
+
+// boolValue returns the boolean held by v, which may be of a named bool
+// type or behind a pointer.
+func boolValue(v any) (bool, bool) {
+	if b, ok := v.(bool); ok {
+		return b, true
+	}
+	if rv := reflect.ValueOf(deref(v)); rv.Kind() == reflect.Bool {
+		return rv.Bool(), true
+	}
+	return false, false
+}
+
+// rewriteFunc is called by rewriteValue for a value before its contents are
+// visited. key is the object key holding v, or "" for array elements. If
+// done is true, v is replaced by replacement and its contents are skipped.
+type rewriteFunc func(path, key string, v any) (replacement any, done bool, err error)
+
+// rewriteObject applies rewriteValue to every value of obj, in key order,
+// storing the results back into obj.
+func rewriteObject(path string, obj map[string]any, fn rewriteFunc) error {
+	for _, k := range sortedKeys(obj) {
+		v, err := rewriteValue(joinPath(path, k), k, obj[k], fn)
+		if err != nil {
+			return err
+		}
+		obj[k] = v
+	}
+	return nil
+}
+
+// rewriteValue applies fn to v and then, unless fn handled it, to every
+// value nested in it. map[string]any and []any containers are updated in
+// place; any other object or array, such as map[string]string,
+// []map[string]any or a pointer to a map, is replaced by a map[string]any or
+// []any copy so that its contents can be rewritten. Byte slices are left
+// alone.
+func rewriteValue(path, key string, v any, fn rewriteFunc) (any, error) {
+	if r, done, err := fn(path, key, v); err != nil || done {
+		return r, err
+	}
+	switch jsonKind(v) {
+	case kindObject:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			obj = toObject(v)
+		}
+		return obj, rewriteObject(path, obj, fn)
+	case kindArray:
+		if _, ok := v.([]byte); ok {
+			return v, nil
+		}
+		arr, ok := v.([]any)
+		if !ok {
+			arr = toArray(v)
+		}
+		for i, item := range arr {
+			r, err := rewriteValue(path+"["+strconv.Itoa(i)+"]", "", item, fn)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = r
+		}
+		return arr, nil
+	}
+	return v, nil
+}
+
+// sortedKeys returns the keys of m in lexicographic order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// plainValue converts typed maps and slices, at any depth, to map[string]any
+// and []any so the value only contains types produced by encoding/json.
+func plainValue(v any) any {
+	switch jsonKind(v) {
+	case kindObject:
+		obj := toObject(v)
+		out := make(map[string]any, len(obj))
+		for k, item := range obj {
+			out[k] = plainValue(item)
+		}
+		return out
+	case kindArray:
+		if b, ok := v.([]byte); ok {
+			return b
+		}
+		arr := toArray(v)
+		out := make([]any, len(arr))
+		for i, item := range arr {
+			out[i] = plainValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+// nativeValue converts v like plainValue and also replaces json.Number with
+// an int64 or float64, for encoders that do not understand json.Number.
+func nativeValue(v any) (any, error) {
+	switch x := plainValue(v).(type) {
+	case map[string]any:
+		for k, item := range x {
+			cv, err := nativeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			x[k] = cv
+		}
+		return x, nil
+	case []any:
+		for i, item := range x {
+			cv, err := nativeValue(item)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = cv
+		}
+		return x, nil
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n, nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", x, err)
+		}
+		return f, nil
+	default:
+		return x, nil
+	}
+}
@@ -0,0 +1,228 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WorkflowStep defines a single named MCP tool execution within a workflow.
+type WorkflowStep struct {
+	Name      string     `json:"name" doc:"The unique name of the step."`
+	Execute   McpExecute `json:"execute" doc:"The tool execution performed by the step."`
+	DependsOn []string   `json:"dependsOn,omitempty" doc:"The names of the steps that must complete before this one."`
+	When      string     `json:"when,omitempty" doc:"A condition over earlier step results, such as \"build.exitCode == 0\", that must hold for the step to run."`
+}
+
+// Workflow defines a directed acyclic graph of MCP tool executions.
+type Workflow struct {
+	Name  string         `json:"name,omitempty" doc:"The name of the workflow."`
+	Steps []WorkflowStep `json:"steps" doc:"The steps of the workflow."`
+}
+
+// Validate checks that step names are unique and non-empty, that every
+// dependency names an existing step, that every execution is well-formed,
+// that the dependency graph has no cycles and that every step a When
+// condition refers to is a direct or transitive dependency.
+func (w Workflow) Validate() error {
+	seen := make(map[string]struct{}, len(w.Steps))
+	for i, s := range w.Steps {
+		if s.Name == "" {
+			return &ValidationError{Field: fmt.Sprintf("steps[%d].name", i), Message: "must not be empty"}
+		}
+		if _, dup := seen[s.Name]; dup {
+			return &ValidationError{Field: fmt.Sprintf("steps[%d].name", i), Message: fmt.Sprintf("duplicate step %q", s.Name)}
+		}
+		seen[s.Name] = struct{}{}
+	}
+	for i, s := range w.Steps {
+		for _, dep := range s.DependsOn {
+			if _, ok := seen[dep]; !ok {
+				return &ValidationError{Field: fmt.Sprintf("steps[%d].dependsOn", i), Message: fmt.Sprintf("unknown step %q", dep)}
+			}
+		}
+		if s.When != "" {
+			cond, err := parseExpr(s.When)
+			if err != nil {
+				return &ValidationError{Field: fmt.Sprintf("steps[%d].when", i), Message: err.Error()}
+			}
+			for _, ref := range cond.steps() {
+				if _, ok := seen[ref]; !ok {
+					return &ValidationError{Field: fmt.Sprintf("steps[%d].when", i), Message: fmt.Sprintf("unknown step %q", ref)}
+				}
+			}
+		}
+		if err := s.Execute.Validate(); err != nil {
+			var verr *ValidationError
+			if errors.As(err, &verr) {
+				return &ValidationError{Field: fmt.Sprintf("steps[%d].execute.%s", i, verr.Field), Message: verr.Message}
+			}
+			return fmt.Errorf("steps[%d]: %w", i, err)
+		}
+	}
+	order, err := w.order()
+	if err != nil {
+		return err
+	}
+	return w.validateWhenRefs(order)
+}
+
+// validateWhenRefs checks that every step referenced by a When condition is
+// a transitive dependency, so it has finished before the condition is
+// evaluated. order must be a topological order of the steps.
+func (w Workflow) validateWhenRefs(order []string) error {
+	index := make(map[string]int, len(w.Steps))
+	for i, s := range w.Steps {
+		index[s.Name] = i
+	}
+	ancestors := make(map[string]map[string]struct{}, len(w.Steps))
+	for _, name := range order {
+		anc := make(map[string]struct{})
+		for _, dep := range w.Steps[index[name]].DependsOn {
+			anc[dep] = struct{}{}
+			for a := range ancestors[dep] {
+				anc[a] = struct{}{}
+			}
+		}
+		ancestors[name] = anc
+	}
+	for i, s := range w.Steps {
+		if s.When == "" {
+			continue
+		}
+		cond, _ := parseExpr(s.When)
+		for _, ref := range cond.steps() {
+			if _, ok := ancestors[s.Name][ref]; !ok {
+				return &ValidationError{Field: fmt.Sprintf("steps[%d].when", i), Message: fmt.Sprintf("step %q is not a dependency", ref)}
+			}
+		}
+	}
+	return nil
+}
+
+// TopologicalOrder returns the step names in an order in which every step
+// follows its dependencies. Ties are broken by declaration order, so the
+// result is deterministic.
+func (w Workflow) TopologicalOrder() ([]string, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return w.order()
+}
+
+// WorkflowPlan lists the steps that can make progress given the results so far.
+type WorkflowPlan struct {
+	Ready   []string `json:"ready" doc:"The steps that are eligible to run now."`
+	Skipped []string `json:"skipped,omitempty" doc:"The steps that will not run because their condition is false or a dependency did not succeed."`
+}
+
+// Plan returns the steps that have not yet run and are now eligible to run.
+// See PlanSteps for the eligibility rules.
+func (w Workflow) Plan(results WorkflowResult) ([]string, error) {
+	p, err := w.PlanSteps(results)
+	return p.Ready, err
+}
+
+// PlanSteps classifies the steps that have neither run nor been skipped in
+// results and whose dependencies have all finished. A step is skipped when
+// any dependency was skipped or, if it has no When condition, when any
+// dependency failed; a step with a When condition runs only if the
+// condition holds. Steps are listed in declaration order.
+func (w Workflow) PlanSteps(results WorkflowResult) (WorkflowPlan, error) {
+	var plan WorkflowPlan
+	if err := w.Validate(); err != nil {
+		return plan, err
+	}
+	skipped := make(map[string]struct{}, len(results.Skipped))
+	for _, name := range results.Skipped {
+		skipped[name] = struct{}{}
+	}
+	for _, s := range w.Steps {
+		if _, ran := results.Results[s.Name]; ran {
+			continue
+		}
+		if _, ok := skipped[s.Name]; ok {
+			continue
+		}
+		finished, depSkipped, depFailed := true, false, false
+		for _, dep := range s.DependsOn {
+			if _, ok := skipped[dep]; ok {
+				depSkipped = true
+				continue
+			}
+			r, ran := results.Results[dep]
+			if !ran {
+				finished = false
+				break
+			}
+			depFailed = depFailed || r.IsError()
+		}
+		if !finished {
+			continue
+		}
+		run := !depSkipped && !depFailed
+		if !depSkipped && s.When != "" {
+			cond, _ := parseExpr(s.When)
+			ok, err := cond.eval(results)
+			if err != nil {
+				return WorkflowPlan{}, &ValidationError{Field: "steps." + s.Name + ".when", Message: err.Error()}
+			}
+			run = ok
+		}
+		if run {
+			plan.Ready = append(plan.Ready, s.Name)
+		} else {
+			plan.Skipped = append(plan.Skipped, s.Name)
+		}
+	}
+	return plan, nil
+}
+
+// order runs Kahn's algorithm over the steps, assuming names are unique and
+// dependencies exist.
+func (w Workflow) order() ([]string, error) {
+	index := make(map[string]int, len(w.Steps))
+	for i, s := range w.Steps {
+		index[s.Name] = i
+	}
+	pending := make([]int, len(w.Steps))
+	dependents := make([][]int, len(w.Steps))
+	for i, s := range w.Steps {
+		for _, dep := range s.DependsOn {
+			pending[i]++
+			dependents[index[dep]] = append(dependents[index[dep]], i)
+		}
+	}
+
+	var ready []int
+	for i := range w.Steps {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	order := make([]string, 0, len(w.Steps))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+		order = append(order, w.Steps[i].Name)
+		for _, d := range dependents[i] {
+			pending[d]--
+			if pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(order) < len(w.Steps) {
+		var cyclic []string
+		for i, s := range w.Steps {
+			if pending[i] > 0 {
+				cyclic = append(cyclic, s.Name)
+			}
+		}
+		return nil, &ValidationError{Field: "steps", Message: "dependency cycle involving " + strings.Join(cyclic, ", ")}
+	}
+	return order, nil
+}
@@ -0,0 +1,90 @@
+package schemas
+
+import "sort"
+
+// WorkflowResult defines the outcome of running a Workflow.
+type WorkflowResult struct {
+	Results map[string]McpResult `json:"results" doc:"The result of each step that ran, keyed by step name."`
+	Order   []string             `json:"order,omitempty" doc:"The names of the steps in the order they ran."`
+	Skipped []string             `json:"skipped,omitempty" doc:"The names of the steps that were skipped."`
+}
+
+// Record stores the result of step and appends it to the execution order.
+// Recording a step again replaces its result but keeps its original position.
+func (wr *WorkflowResult) Record(step string, r McpResult) {
+	if wr.Results == nil {
+		wr.Results = make(map[string]McpResult)
+	}
+	if _, seen := wr.Results[step]; !seen {
+		wr.Order = append(wr.Order, step)
+	}
+	wr.Results[step] = r
+}
+
+// Skip marks step as skipped so that Workflow.Plan no longer offers it.
+func (wr *WorkflowResult) Skip(steps ...string) {
+	wr.Skipped = append(wr.Skipped, steps...)
+}
+
+// Failed returns the names of the steps whose result is an error, in execution order.
+func (wr WorkflowResult) Failed() []string {
+	return wr.filter(func(r McpResult) bool { return r.IsError() })
+}
+
+// Succeeded returns the names of the steps whose result is not an error, in execution order.
+func (wr WorkflowResult) Succeeded() []string {
+	return wr.filter(func(r McpResult) bool { return !r.IsError() })
+}
+
+// Output returns the output of step if it ran and succeeded.
+func (wr WorkflowResult) Output(step string) (any, bool) {
+	r, ok := wr.Results[step]
+	if !ok || r.IsError() {
+		return nil, false
+	}
+	return r.Output, true
+}
+
+// FirstError returns the structured error of the earliest failed step in
+// execution order, or nil if no step failed.
+func (wr WorkflowResult) FirstError() *McpError {
+	for _, step := range wr.steps() {
+		if serr, failed := wr.Results[step].StructuredError(); failed {
+			return serr
+		}
+	}
+	return nil
+}
+
+func (wr WorkflowResult) filter(keep func(McpResult) bool) []string {
+	var names []string
+	for _, step := range wr.steps() {
+		if keep(wr.Results[step]) {
+			names = append(names, step)
+		}
+	}
+	return names
+}
+
+// steps returns the steps with results in execution order, followed by any
+// results missing from Order sorted by name.
+func (wr WorkflowResult) steps() []string {
+	steps := make([]string, 0, len(wr.Results))
+	listed := make(map[string]struct{}, len(wr.Order))
+	for _, step := range wr.Order {
+		if _, ok := wr.Results[step]; ok {
+			if _, dup := listed[step]; !dup {
+				steps = append(steps, step)
+				listed[step] = struct{}{}
+			}
+		}
+	}
+	var rest []string
+	for step := range wr.Results {
+		if _, ok := listed[step]; !ok {
+			rest = append(rest, step)
+		}
+	}
+	sort.Strings(rest)
+	return append(steps, rest...)
+}
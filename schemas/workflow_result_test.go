@@ -0,0 +1,33 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowResult(t *testing.T) {
+	var wr WorkflowResult
+	wr.Record("build", McpResult{Tool: "build", Output: "ok"})
+	wr.Record("test", ErrorResult("test", &McpError{Code: ToolInternal, Message: "tests failed"}))
+	wr.Record("lint", McpResult{Tool: "lint", Error: "lint failed"})
+	wr.Record("docs", McpResult{Tool: "docs"})
+
+	if got := wr.Failed(); !reflect.DeepEqual(got, []string{"test", "lint"}) {
+		t.Errorf("Failed() = %v", got)
+	}
+	if got := wr.Succeeded(); !reflect.DeepEqual(got, []string{"build", "docs"}) {
+		t.Errorf("Succeeded() = %v", got)
+	}
+	if out, ok := wr.Output("build"); !ok || out != "ok" {
+		t.Errorf("Output(build) = %v, %v", out, ok)
+	}
+	if _, ok := wr.Output("test"); ok {
+		t.Error("Output of failed step reported")
+	}
+	if e := wr.FirstError(); e == nil || e.Code != ToolInternal {
+		t.Errorf("FirstError() = %v", e)
+	}
+	if e := (WorkflowResult{}).FirstError(); e != nil {
+		t.Errorf("FirstError() of empty result = %v", e)
+	}
+}
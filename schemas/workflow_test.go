@@ -0,0 +1,121 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func step(name string, deps ...string) WorkflowStep {
+	return WorkflowStep{Name: name, Execute: McpExecute{Tool: "tool_" + name}, DependsOn: deps}
+}
+
+func TestWorkflowTopologicalOrder(t *testing.T) {
+	w := Workflow{Steps: []WorkflowStep{
+		step("deploy", "build", "test"),
+		step("build"),
+		step("test", "build"),
+		step("lint"),
+	}}
+	got, err := w.TopologicalOrder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"build", "test", "deploy", "lint"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	tests := map[string]Workflow{
+		"duplicate":    {Steps: []WorkflowStep{step("a"), step("a")}},
+		"empty name":   {Steps: []WorkflowStep{step("")}},
+		"missing dep":  {Steps: []WorkflowStep{step("a", "ghost")}},
+		"self cycle":   {Steps: []WorkflowStep{step("a", "a")}},
+		"cycle":        {Steps: []WorkflowStep{step("a", "c"), step("b", "a"), step("c", "b")}},
+		"invalid tool": {Steps: []WorkflowStep{{Name: "a"}}},
+	}
+	for name, w := range tests {
+		if err := w.Validate(); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if _, err := w.TopologicalOrder(); err == nil {
+			t.Errorf("%s: expected TopologicalOrder error", name)
+		}
+	}
+}
+
+func TestWorkflowPlan(t *testing.T) {
+	w := Workflow{Steps: []WorkflowStep{
+		step("build"),
+		step("test", "build"),
+		step("deploy", "test"),
+		step("notify", "test"),
+		step("after_deploy", "deploy"),
+	}}
+	w.Steps[2].When = "test.exitCode == 0"
+	w.Steps[3].When = "test.isError"
+
+	var results WorkflowResult
+	plan, err := w.PlanSteps(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(plan.Ready, []string{"build"}) || plan.Skipped != nil {
+		t.Errorf("initial plan = %+v", plan)
+	}
+
+	results.Record("build", McpResult{Tool: "tool_build"})
+	results.Record("test", McpResult{Tool: "tool_test", ExitCode: 1, Error: "tests failed"})
+	plan, err = w.PlanSteps(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(plan.Ready, []string{"notify"}) || !reflect.DeepEqual(plan.Skipped, []string{"deploy"}) {
+		t.Errorf("plan after failed test = %+v", plan)
+	}
+
+	results.Skip(plan.Skipped...)
+	ready, err := w.Plan(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ready, []string{"notify"}) {
+		t.Errorf("Plan() = %v", ready)
+	}
+	plan, _ = w.PlanSteps(results)
+	if !reflect.DeepEqual(plan.Skipped, []string{"after_deploy"}) {
+		t.Errorf("skip did not cascade: %+v", plan)
+	}
+}
+
+func TestWorkflowValidateWhen(t *testing.T) {
+	bad := Workflow{Steps: []WorkflowStep{step("a"), step("b", "a")}}
+	bad.Steps[1].When = "a.exitCode =="
+	if err := bad.Validate(); err == nil {
+		t.Error("invalid condition accepted")
+	}
+	bad.Steps[1].When = "ghost.exitCode == 0"
+	if err := bad.Validate(); err == nil {
+		t.Error("condition referencing unknown step accepted")
+	}
+
+	w := Workflow{Steps: []WorkflowStep{step("a"), step("b"), step("c", "b"), step("d", "c")}}
+	w.Steps[3].When = "b.exitCode == 0"
+	if err := w.Validate(); err != nil {
+		t.Errorf("transitive dependency rejected: %v", err)
+	}
+	w.Steps[3].When = "a.exitCode == 0"
+	var verr *ValidationError
+	if err := w.Validate(); !errors.As(err, &verr) || verr.Field != "steps[3].when" {
+		t.Errorf("condition on a non-dependency: got %v", err)
+	}
+	if plan, err := w.PlanSteps(WorkflowResult{}); err == nil {
+		t.Errorf("PlanSteps planned %+v before a ran", plan)
+	}
+	w.Steps[3].When = "d.isError"
+	if err := w.Validate(); err == nil {
+		t.Error("condition on the step itself accepted")
+	}
+}
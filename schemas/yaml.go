@@ -0,0 +1,43 @@
+package schemas
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML decodes an execution request from YAML. Nested mappings are
+// normalized to map[string]any, with non-string keys formatted as strings,
+// so the result can be marshaled to JSON.
+func UnmarshalYAML(data []byte) (McpExecute, error) {
+	var m McpExecute
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return McpExecute{}, err
+	}
+	for k, v := range m.Parameters {
+		m.Parameters[k] = normalizeYAML(v)
+	}
+	return m, nil
+}
+
+func normalizeYAML(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, item := range x {
+			x[k] = normalizeYAML(item)
+		}
+		return x
+	case map[any]any:
+		out := make(map[string]any, len(x))
+		for k, item := range x {
+			out[fmt.Sprint(k)] = normalizeYAML(item)
+		}
+		return out
+	case []any:
+		for i, item := range x {
+			x[i] = normalizeYAML(item)
+		}
+		return x
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package schemas
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnmarshalYAML(t *testing.T) {
+	in := `
+tool: deploy
+schemaVersion: 1
+parameters:
+  service: api
+  ports:
+    - 80
+    - {1: tcp, true: enabled}
+  matrix:
+    2: {3: nested}
+    name: x
+`
+	m, err := UnmarshalYAML([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := McpExecute{Tool: "deploy", SchemaVersion: 1, Parameters: map[string]any{
+		"service": "api",
+		"ports":   []any{80, map[string]any{"1": "tcp", "true": "enabled"}},
+		"matrix":  map[string]any{"2": map[string]any{"3": "nested"}, "name": "x"},
+	}}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("UnmarshalYAML = %#v, want %#v", m, want)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("re-marshal to JSON: %v", err)
+	}
+	var back McpExecute
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.SchemaVersion != 1 || back.Tool != "deploy" {
+		t.Errorf("JSON round trip = %+v", back)
+	}
+	if got := back.Parameters["matrix"].(map[string]any)["2"]; !reflect.DeepEqual(got, map[string]any{"3": "nested"}) {
+		t.Errorf("nested non-string keys after JSON = %#v", got)
+	}
+
+	out, err := yaml.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := UnmarshalYAML(out); err != nil || !reflect.DeepEqual(again, want) {
+		t.Errorf("YAML round trip = %#v, %v", again, err)
+	}
+
+	if _, err := UnmarshalYAML([]byte("tool: [unclosed")); err == nil {
+		t.Error("expected error for malformed YAML")
+	}
+}